@@ -8,14 +8,19 @@ import (
 )
 
 type ArrayExpression struct {
-	Token        token.Token // '(' token
-	Elements    []Expression
+	Token    token.Token // '[' token
+	Elements []Expression
+	Rbrkt    token.Token // ']' token
 }
 
 func (this *ArrayExpression) expressionNode() {}
 
 func (this ArrayExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this ArrayExpression) Pos() token.Position { return this.Token.Span.Start }
+
+func (this ArrayExpression) End() token.Position { return this.Rbrkt.Span.End }
+
 func (this ArrayExpression) String() string {
 	args := []string{}
 	for _, arg := range this.Elements {
@@ -0,0 +1,29 @@
+package ast
+
+import (
+	"fmt"
+
+	"monkey/token"
+)
+
+// AssignExpression is '=' or compound assignment like '+='. Target is
+// checked by the parser, it is Identifier, IndexExpression or
+// MemberExpression
+type AssignExpression struct {
+	Token    token.Token // '=', '+=', ... token
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
+func (this *AssignExpression) expressionNode() {}
+
+func (this AssignExpression) TokenLiteral() string { return this.Token.Literal }
+
+func (this AssignExpression) Pos() token.Position { return this.Target.Pos() }
+
+func (this AssignExpression) End() token.Position { return this.Value.End() }
+
+func (this AssignExpression) String() string {
+	return fmt.Sprintf("(%s %s %s)", this.Target.String(), this.Operator, this.Value.String())
+}
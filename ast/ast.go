@@ -1,8 +1,14 @@
 package ast
 
+import "monkey/token"
+
 type Node interface {
 	TokenLiteral() string
 	String() string
+	// Pos is position of the first character of the node
+	Pos() token.Position
+	// End is position right after the last character of the node
+	End() token.Position
 }
 
 type Statement interface {
@@ -9,12 +9,17 @@ import (
 type BlockExpression struct {
 	Token      token.Token // '{' token
 	Statements []Statement
+	Rbrace     token.Token // '}' token
 }
 
 func (be BlockExpression) expressionNode() {}
 
 func (be BlockExpression) TokenLiteral() string { return be.Token.Literal }
 
+func (be BlockExpression) Pos() token.Position { return be.Token.Span.Start }
+
+func (be BlockExpression) End() token.Position { return be.Rbrace.Span.End }
+
 func (be BlockExpression) String() string {
 	sb := strings.Builder{}
 	sb.WriteString("{")
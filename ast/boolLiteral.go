@@ -9,6 +9,8 @@ type BoolLiteral struct {
 	Value bool
 }
 
-func (this *BoolLiteral) expressionNode()      {}
+func (this *BoolLiteral) expressionNode()     {}
 func (this BoolLiteral) TokenLiteral() string { return this.Token.Literal }
 func (this BoolLiteral) String() string       { return this.Token.Literal }
+func (this BoolLiteral) Pos() token.Position  { return this.Token.Span.Start }
+func (this BoolLiteral) End() token.Position  { return this.Token.Span.End }
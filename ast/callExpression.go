@@ -11,12 +11,17 @@ type CallExpression struct {
 	Token        token.Token // '(' token
 	FnIdentifier Expression  // identifier like 'add' or fn expression
 	Arguments    []Expression
+	Rparen       token.Token // ')' token
 }
 
 func (this *CallExpression) expressionNode() {}
 
 func (this CallExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this CallExpression) Pos() token.Position { return this.FnIdentifier.Pos() }
+
+func (this CallExpression) End() token.Position { return this.Rparen.Span.End }
+
 func (this CallExpression) String() string {
 	args := []string{}
 	for _, arg := range this.Arguments {
@@ -11,8 +11,10 @@ type ExpressionStatement struct {
 	Expression Expression
 }
 
-func (this *ExpressionStatement) statementNode()       {}
+func (this *ExpressionStatement) statementNode()      {}
 func (this ExpressionStatement) TokenLiteral() string { return this.Token.Literal }
+func (this ExpressionStatement) Pos() token.Position  { return this.Expression.Pos() }
+func (this ExpressionStatement) End() token.Position  { return this.Expression.End() }
 func (this ExpressionStatement) String() string {
 	return fmt.Sprintf("%s;", this.Expression.String())
 }
@@ -0,0 +1,16 @@
+package ast
+
+import (
+	"monkey/token"
+)
+
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (this *FloatLiteral) expressionNode()     {}
+func (this FloatLiteral) TokenLiteral() string { return this.Token.Literal }
+func (this FloatLiteral) String() string       { return this.Token.Literal }
+func (this FloatLiteral) Pos() token.Position  { return this.Token.Span.Start }
+func (this FloatLiteral) End() token.Position  { return this.Token.Span.End }
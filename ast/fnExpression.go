@@ -10,17 +10,44 @@ import (
 type FnExpression struct {
 	Token     token.Token // 'fn' token
 	Arguments []*Identifier
-	Body      *BlockExpression
+	// default values of arguments at the same positions, nil for ones
+	// without it. They are evaluated at call when argument is not
+	// passed: fn(a, b = 10)
+	Defaults []Expression
+	// collects arguments after the named ones into array: fn(a, ...rest)
+	Rest *Identifier
+	Body *BlockExpression
 }
 
 func (it FnExpression) expressionNode() {}
 
 func (it FnExpression) TokenLiteral() string { return it.Token.Literal }
 
+func (it FnExpression) Pos() token.Position { return it.Token.Span.Start }
+
+func (it FnExpression) End() token.Position { return it.Body.End() }
+
 func (it FnExpression) String() string {
+	return fmt.Sprintf(
+		"%s(%s)%s",
+		it.TokenLiteral(),
+		ParametersString(it.Arguments, it.Defaults, it.Rest),
+		it.Body.String(),
+	)
+}
+
+// ParametersString renders function parameters: 'a, b = 10, ...rest'
+func ParametersString(arguments []*Identifier, defaults []Expression, rest *Identifier) string {
 	args := []string{}
-	for _, arg := range it.Arguments {
+	for i, arg := range arguments {
+		if i < len(defaults) && defaults[i] != nil {
+			args = append(args, arg.String()+" = "+defaults[i].String())
+			continue
+		}
 		args = append(args, arg.String())
 	}
-	return fmt.Sprintf("%s(%s)%s", it.TokenLiteral(), strings.Join(args, ", "), it.Body.String())
+	if rest != nil {
+		args = append(args, "..."+rest.String())
+	}
+	return strings.Join(args, ", ")
 }
@@ -0,0 +1,40 @@
+package ast
+
+import (
+	"strings"
+
+	"monkey/token"
+)
+
+// ForStatement is 'for (value in iterable) { }' or
+// 'for (key, value in iterable) { }'. Key is nil in the first form
+type ForStatement struct {
+	Token    token.Token // 'for' token
+	Key      *Identifier // optional
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockExpression
+}
+
+func (this *ForStatement) statementNode() {}
+
+func (this ForStatement) TokenLiteral() string { return this.Token.Literal }
+
+func (this ForStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this ForStatement) End() token.Position { return this.Body.End() }
+
+func (this ForStatement) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("for (")
+	if this.Key != nil {
+		sb.WriteString(this.Key.String())
+		sb.WriteString(", ")
+	}
+	sb.WriteString(this.Value.String())
+	sb.WriteString(" in ")
+	sb.WriteString(this.Iterable.String())
+	sb.WriteString(")")
+	sb.WriteString(this.Body.String())
+	return sb.String()
+}
@@ -8,14 +8,19 @@ import (
 )
 
 type HashExpression struct {
-	Token token.Token // '(' token
-	Map   map[Expression]Expression
+	Token  token.Token // '#' token
+	Map    map[Expression]Expression
+	Rbrace token.Token // '}' token
 }
 
 func (this *HashExpression) expressionNode() {}
 
 func (this HashExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this HashExpression) Pos() token.Position { return this.Token.Span.Start }
+
+func (this HashExpression) End() token.Position { return this.Rbrace.Span.End }
+
 func (this HashExpression) String() string {
 	strs := []string{}
 	for k, v := range this.Map {
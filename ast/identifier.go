@@ -7,8 +7,10 @@ type Identifier struct {
 	Value string
 }
 
-func (this *Identifier) expressionNode()      {}
+func (this *Identifier) expressionNode()     {}
 func (this Identifier) TokenLiteral() string { return this.Token.Literal }
 func (this Identifier) String() string {
 	return this.Value
 }
+func (this Identifier) Pos() token.Position { return this.Token.Span.Start }
+func (this Identifier) End() token.Position { return this.Token.Span.End }
@@ -10,7 +10,7 @@ type IfExpression struct {
 	Token        token.Token // 'if' token
 	Condition    Expression
 	IfBlock      *BlockExpression
-	ElseIfBlocks []*ElseIfBlock    // optional
+	ElseIfBlocks []*ElseIfBlock   // optional
 	ElseBlock    *BlockExpression // optional
 }
 
@@ -18,6 +18,18 @@ func (this *IfExpression) expressionNode() {}
 
 func (this IfExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this IfExpression) Pos() token.Position { return this.Token.Span.Start }
+
+func (this IfExpression) End() token.Position {
+	if this.ElseBlock != nil {
+		return this.ElseBlock.End()
+	}
+	if len(this.ElseIfBlocks) > 0 {
+		return this.ElseIfBlocks[len(this.ElseIfBlocks)-1].End()
+	}
+	return this.IfBlock.End()
+}
+
 func (this IfExpression) String() string {
 	sb := strings.Builder{}
 	sb.WriteString("if (")
@@ -45,6 +57,12 @@ type ElseIfBlock struct {
 	Block     *BlockExpression
 }
 
+func (this ElseIfBlock) TokenLiteral() string { return this.Token.Literal }
+
+func (this ElseIfBlock) Pos() token.Position { return this.Token.Span.Start }
+
+func (this ElseIfBlock) End() token.Position { return this.Block.End() }
+
 func (this ElseIfBlock) String() string {
 	sb := strings.Builder{}
 	sb.WriteString("else if (")
@@ -7,15 +7,22 @@ import (
 )
 
 type IndexExpression struct {
-	Token           token.Token // '[' token
+	Token           token.Token // '[' or '?[' token
 	Identifier      Expression  // identifier like 'arr'/'myMap' or arr/hash literal
 	IndexExpression Expression
+	Rbrkt           token.Token // ']' token
+	// Optional is true for 'a?[i]', it is null when 'a' is null
+	Optional bool
 }
 
 func (this *IndexExpression) expressionNode() {}
 
 func (this IndexExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this IndexExpression) Pos() token.Position { return this.Identifier.Pos() }
+
+func (this IndexExpression) End() token.Position { return this.Rbrkt.Span.End }
+
 func (this IndexExpression) String() string {
-	return fmt.Sprintf("%s[%s]", this.Identifier.String(), this.IndexExpression.String())
+	return fmt.Sprintf("%s%s%s]", this.Identifier.String(), this.Token.Literal, this.IndexExpression.String())
 }
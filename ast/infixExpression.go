@@ -12,8 +12,10 @@ type InfixExpression struct {
 	Right    Expression
 }
 
-func (this *InfixExpression) expressionNode()      {}
+func (this *InfixExpression) expressionNode()     {}
 func (this InfixExpression) TokenLiteral() string { return this.Token.Literal }
+func (this InfixExpression) Pos() token.Position  { return this.Left.Pos() }
+func (this InfixExpression) End() token.Position  { return this.Right.End() }
 func (this InfixExpression) String() string {
 	return fmt.Sprintf("(%s %s %s)", this.Left.String(), this.Operator, this.Right.String())
 }
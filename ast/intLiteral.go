@@ -9,6 +9,8 @@ type IntLiteral struct {
 	Value int64
 }
 
-func (this *IntLiteral) expressionNode()      {}
+func (this *IntLiteral) expressionNode()     {}
 func (this IntLiteral) TokenLiteral() string { return this.Token.Literal }
 func (this IntLiteral) String() string       { return this.Token.Literal }
+func (this IntLiteral) Pos() token.Position  { return this.Token.Span.Start }
+func (this IntLiteral) End() token.Position  { return this.Token.Span.End }
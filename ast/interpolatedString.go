@@ -0,0 +1,40 @@
+package ast
+
+import (
+	"strings"
+
+	"monkey/token"
+)
+
+// InterpolatedString is "text ${expression} text". Texts always has one
+// element more than Expressions: text before, between and after them,
+// empty text parts are kept as empty literals
+type InterpolatedString struct {
+	Token       token.Token // STRING_HEAD token
+	Texts       []*StringLiteral
+	Expressions []Expression
+	Tail        token.Token // STRING_TAIL token
+}
+
+func (this *InterpolatedString) expressionNode() {}
+
+func (this InterpolatedString) TokenLiteral() string { return this.Token.Literal }
+
+func (this InterpolatedString) Pos() token.Position { return this.Token.Span.Start }
+
+func (this InterpolatedString) End() token.Position { return this.Tail.Span.End }
+
+func (this InterpolatedString) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(`"`)
+	for i, text := range this.Texts {
+		sb.WriteString(text.Value)
+		if i < len(this.Expressions) {
+			sb.WriteString("${")
+			sb.WriteString(this.Expressions[i].String())
+			sb.WriteString("}")
+		}
+	}
+	sb.WriteString(`"`)
+	return sb.String()
+}
@@ -6,16 +6,27 @@ import (
 	"monkey/token"
 )
 
+// LetStatement declares a variable, or a constant when it starts with
+// 'const' instead of 'let'
 type LetStatement struct {
-	Token token.Token // 'let' token
-	Identifier  *Identifier
-	Value Expression
+	Token      token.Token // 'let' or 'const' token
+	Identifier *Identifier
+	Value      Expression
+	Constant   bool
 }
 
 func (this *LetStatement) statementNode() {}
 
 func (this LetStatement) TokenLiteral() string { return this.Token.Literal }
 
+func (this LetStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this LetStatement) End() token.Position { return this.Value.End() }
+
 func (this LetStatement) String() string {
-	return fmt.Sprintf("let %s = %s;", this.Identifier.String(), this.Value.String())
+	keyword := "let"
+	if this.Constant {
+		keyword = "const"
+	}
+	return fmt.Sprintf("%s %s = %s;", keyword, this.Identifier.String(), this.Value.String())
 }
@@ -0,0 +1,33 @@
+package ast
+
+import "monkey/token"
+
+// BreakStatement leaves the innermost loop
+type BreakStatement struct {
+	Token token.Token // 'break' token
+}
+
+func (this *BreakStatement) statementNode() {}
+
+func (this BreakStatement) TokenLiteral() string { return this.Token.Literal }
+
+func (this BreakStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this BreakStatement) End() token.Position { return this.Token.Span.End }
+
+func (this BreakStatement) String() string { return "break;" }
+
+// ContinueStatement goes to the next iteration of the innermost loop
+type ContinueStatement struct {
+	Token token.Token // 'continue' token
+}
+
+func (this *ContinueStatement) statementNode() {}
+
+func (this ContinueStatement) TokenLiteral() string { return this.Token.Literal }
+
+func (this ContinueStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this ContinueStatement) End() token.Position { return this.Token.Span.End }
+
+func (this ContinueStatement) String() string { return "continue;" }
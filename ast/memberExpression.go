@@ -0,0 +1,29 @@
+package ast
+
+import (
+	"fmt"
+
+	"monkey/token"
+)
+
+// MemberExpression is 'person.name' or 'cfg?.host', lookup of a string
+// key in a hash written as a name. Optional one is also null when Object
+// is null
+type MemberExpression struct {
+	Token    token.Token // '.' or '?.' token
+	Object   Expression
+	Property *Identifier
+	Optional bool
+}
+
+func (this *MemberExpression) expressionNode() {}
+
+func (this MemberExpression) TokenLiteral() string { return this.Token.Literal }
+
+func (this MemberExpression) Pos() token.Position { return this.Object.Pos() }
+
+func (this MemberExpression) End() token.Position { return this.Property.End() }
+
+func (this MemberExpression) String() string {
+	return fmt.Sprintf("%s%s%s", this.Object.String(), this.Token.Literal, this.Property.String())
+}
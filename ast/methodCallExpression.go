@@ -0,0 +1,46 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"monkey/token"
+)
+
+// MethodCallExpression is 'receiver.method(args)', builtins are called
+// with receiver as the first argument: 'arr.push(4)' is 'push(arr, 4)'
+type MethodCallExpression struct {
+	Token     token.Token // '(' token
+	Receiver  Expression
+	Method    *Identifier
+	Arguments []Expression
+	Rparen    token.Token // ')' token
+	// Optional is true for 'a?.method()', it is null when 'a' is null
+	Optional bool
+}
+
+func (this *MethodCallExpression) expressionNode() {}
+
+func (this MethodCallExpression) TokenLiteral() string { return this.Token.Literal }
+
+func (this MethodCallExpression) Pos() token.Position { return this.Receiver.Pos() }
+
+func (this MethodCallExpression) End() token.Position { return this.Rparen.Span.End }
+
+func (this MethodCallExpression) String() string {
+	args := []string{}
+	for _, arg := range this.Arguments {
+		args = append(args, arg.String())
+	}
+	dot := "."
+	if this.Optional {
+		dot = "?."
+	}
+	return fmt.Sprintf(
+		"%s%s%s(%s)",
+		this.Receiver.String(),
+		dot,
+		this.Method.String(),
+		strings.Join(args, ", "),
+	)
+}
@@ -0,0 +1,15 @@
+package ast
+
+import (
+	"monkey/token"
+)
+
+type NullLiteral struct {
+	Token token.Token
+}
+
+func (this *NullLiteral) expressionNode()     {}
+func (this NullLiteral) TokenLiteral() string { return this.Token.Literal }
+func (this NullLiteral) String() string       { return this.Token.Literal }
+func (this NullLiteral) Pos() token.Position  { return this.Token.Span.Start }
+func (this NullLiteral) End() token.Position  { return this.Token.Span.End }
@@ -16,6 +16,10 @@ func (this *PrefixExpression) expressionNode() {}
 
 func (this PrefixExpression) TokenLiteral() string { return this.Token.Literal }
 
+func (this PrefixExpression) Pos() token.Position { return this.Token.Span.Start }
+
+func (this PrefixExpression) End() token.Position { return this.Value.End() }
+
 func (this PrefixExpression) String() string {
 	return fmt.Sprintf("(%s%s)", this.Operator, this.Value.String())
 }
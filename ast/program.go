@@ -3,6 +3,8 @@ package ast
 import (
 	"bytes"
 	"fmt"
+
+	"monkey/token"
 )
 
 type Program struct {
@@ -13,6 +15,20 @@ func (this Program) TokenLiteral() string {
 	return ""
 }
 
+func (this Program) Pos() token.Position {
+	if len(this.Statements) == 0 {
+		return token.Position{}
+	}
+	return this.Statements[0].Pos()
+}
+
+func (this Program) End() token.Position {
+	if len(this.Statements) == 0 {
+		return token.Position{}
+	}
+	return this.Statements[len(this.Statements)-1].End()
+}
+
 func (this Program) String() string {
 	var buf bytes.Buffer
 
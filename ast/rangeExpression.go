@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"strings"
+
+	"monkey/token"
+)
+
+// RangeExpression is 'start..stop' or 'start..=stop' with optional
+// 'step n' after it
+type RangeExpression struct {
+	Token     token.Token // '..' or '..=' token
+	Start     Expression
+	Stop      Expression
+	Inclusive bool
+	Step      Expression // optional
+}
+
+func (this *RangeExpression) expressionNode() {}
+
+func (this RangeExpression) TokenLiteral() string { return this.Token.Literal }
+
+func (this RangeExpression) Pos() token.Position { return this.Start.Pos() }
+
+func (this RangeExpression) End() token.Position {
+	if this.Step != nil {
+		return this.Step.End()
+	}
+	return this.Stop.End()
+}
+
+func (this RangeExpression) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("(")
+	sb.WriteString(this.Start.String())
+	sb.WriteString(this.Token.Literal)
+	sb.WriteString(this.Stop.String())
+	if this.Step != nil {
+		sb.WriteString(" step ")
+		sb.WriteString(this.Step.String())
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
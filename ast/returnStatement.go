@@ -15,6 +15,15 @@ func (this *ReturnStatement) statementNode() {}
 
 func (this ReturnStatement) TokenLiteral() string { return this.Token.Literal }
 
+func (this ReturnStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this ReturnStatement) End() token.Position {
+	if this.Value == nil {
+		return this.Token.Span.End
+	}
+	return this.Value.End()
+}
+
 func (this ReturnStatement) String() string {
 	if this.Value == nil {
 		return "return;"
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"strings"
+
+	"monkey/token"
+)
+
+// SliceExpression is 'arr[start:stop]' or 'arr[start:stop:step]', every
+// part is optional: 'arr[:3]', 'arr[1:]', 'arr[::-1]'. Missing parts are nil
+type SliceExpression struct {
+	Token      token.Token // '[' or '?[' token
+	Identifier Expression  // sliced array or string
+	Start      Expression
+	Stop       Expression
+	Step       Expression
+	Rbrkt      token.Token // ']' token
+	// Optional is true for 'a?[1:]', it is null when 'a' is null
+	Optional bool
+}
+
+func (this *SliceExpression) expressionNode() {}
+
+func (this SliceExpression) TokenLiteral() string { return this.Token.Literal }
+
+func (this SliceExpression) Pos() token.Position { return this.Identifier.Pos() }
+
+func (this SliceExpression) End() token.Position { return this.Rbrkt.Span.End }
+
+func (this SliceExpression) String() string {
+	parts := []string{}
+	for _, part := range []Expression{this.Start, this.Stop, this.Step} {
+		if part == nil {
+			parts = append(parts, "")
+		} else {
+			parts = append(parts, part.String())
+		}
+	}
+	if this.Step == nil {
+		parts = parts[:2]
+	}
+	return this.Identifier.String() + this.Token.Literal + strings.Join(parts, ":") + "]"
+}
@@ -9,6 +9,8 @@ type StringLiteral struct {
 	Value string
 }
 
-func (this *StringLiteral) expressionNode()      {}
+func (this *StringLiteral) expressionNode()     {}
 func (this StringLiteral) TokenLiteral() string { return this.Token.Literal }
 func (this StringLiteral) String() string       { return this.Token.Literal }
+func (this StringLiteral) Pos() token.Position  { return this.Token.Span.Start }
+func (this StringLiteral) End() token.Position  { return this.Token.Span.End }
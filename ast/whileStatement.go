@@ -0,0 +1,30 @@
+package ast
+
+import (
+	"strings"
+
+	"monkey/token"
+)
+
+type WhileStatement struct {
+	Token     token.Token // 'while' token
+	Condition Expression
+	Body      *BlockExpression
+}
+
+func (this *WhileStatement) statementNode() {}
+
+func (this WhileStatement) TokenLiteral() string { return this.Token.Literal }
+
+func (this WhileStatement) Pos() token.Position { return this.Token.Span.Start }
+
+func (this WhileStatement) End() token.Position { return this.Body.End() }
+
+func (this WhileStatement) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("while (")
+	sb.WriteString(this.Condition.String())
+	sb.WriteString(")")
+	sb.WriteString(this.Body.String())
+	return sb.String()
+}
@@ -25,7 +25,9 @@ func Cmd() {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		Run(content)
+		Run(os.Args[2], content)
+	case "tokens", "ast":
+		inspect(os.Args[1], os.Args[2:])
 	default:
 		fmt.Println("Unknkown command", fmt.Sprintf("%v", os.Args[1:]))
 		os.Exit(1)
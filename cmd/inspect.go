@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"monkey/ast"
+	"monkey/lexer"
+	"monkey/parser"
+	"monkey/token"
+)
+
+// jsonPosition is token.Position without the file name, which is the same
+// for the whole dump
+type jsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+func toJSONPosition(p token.Position) jsonPosition {
+	return jsonPosition{Line: p.Line, Column: p.Column, Offset: p.Offset}
+}
+
+// lineColumn formats position without the file name
+func lineColumn(p token.Position) string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// DumpTokens writes every token of the content including comments and the
+// final EOF, one per line or as JSON array. Lexer errors are returned
+func DumpTokens(out io.Writer, filename, content string, asJSON bool) []error {
+	l := lexer.NewFile(filename, content)
+	l.KeepComments(true)
+
+	tokens := []token.Token{}
+	for {
+		t := l.NextToken()
+		tokens = append(tokens, t)
+		if t.Type == token.EOF {
+			break
+		}
+	}
+
+	if asJSON {
+		type jsonToken struct {
+			Type    token.TokenType `json:"type"`
+			Literal string          `json:"literal"`
+			Start   jsonPosition    `json:"start"`
+			End     jsonPosition    `json:"end"`
+		}
+		dumped := make([]jsonToken, 0, len(tokens))
+		for _, t := range tokens {
+			dumped = append(dumped, jsonToken{
+				Type:    t.Type,
+				Literal: t.Literal,
+				Start:   toJSONPosition(t.Span.Start),
+				End:     toJSONPosition(t.Span.End),
+			})
+		}
+		writeJSON(out, dumped)
+		return l.Errors()
+	}
+
+	for _, t := range tokens {
+		span := lineColumn(t.Span.Start) + "-" + lineColumn(t.Span.End)
+		fmt.Fprintf(out, "%-12s %-14s %q\n", span, t.Type, t.Literal)
+	}
+	return l.Errors()
+}
+
+// DumpAst writes parsed program as indented tree or as JSON. Program is
+// dumped even when there are errors, parts which failed to parse are
+// missing from it. Lexer and parser errors are returned
+func DumpAst(out io.Writer, filename, content string, asJSON bool) []error {
+	p := parser.New(lexer.NewFile(filename, content))
+	program := p.ParseProgram()
+	root := dumpNode(reflect.ValueOf(program))
+
+	if asJSON {
+		writeJSON(out, root.toJSON())
+	} else {
+		root.writeTree(out, "", "")
+	}
+	return p.Errors()
+}
+
+func writeJSON(out io.Writer, v any) {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	// only fails on unsupported values, dumps are made of plain values
+	_ = encoder.Encode(v)
+}
+
+// dumpedNode is a generic view of an ast node, it is built by reflection
+// so new node types show up in dumps without changes here
+type dumpedNode struct {
+	Type     string
+	Pos, End token.Position
+	// Attrs are scalar fields like operator or literal value
+	Attrs []dumpedAttr
+	// Children are fields holding other nodes
+	Children []dumpedChild
+}
+
+type dumpedAttr struct {
+	Name  string
+	Value any
+}
+
+type dumpedChild struct {
+	Name string
+	// List is true for slice fields, Nodes can have any length then.
+	// Otherwise Nodes has one element, nil for missing optional node
+	List  bool
+	Nodes []*dumpedNode
+}
+
+var (
+	nodeType  = reflect.TypeOf((*ast.Node)(nil)).Elem()
+	tokenType = reflect.TypeOf(token.Token{})
+)
+
+func dumpNode(v reflect.Value) *dumpedNode {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil
+	}
+
+	dumped := &dumpedNode{}
+	if v.Type().Implements(nodeType) {
+		node := v.Interface().(ast.Node)
+		dumped.Pos, dumped.End = node.Pos(), node.End()
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	dumped.Type = v.Type().Name()
+	if v.Kind() != reflect.Struct {
+		// not a node, like a string key of a map, it is shown as its value
+		dumped.Type = v.Type().String()
+		dumped.Attrs = []dumpedAttr{{Name: "Value", Value: v.Interface()}}
+		return dumped
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		if !field.IsExported() || field.Type == tokenType {
+			// tokens are covered by node position and attributes
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.Slice:
+			child := dumpedChild{Name: field.Name, List: true, Nodes: []*dumpedNode{}}
+			for j := 0; j < value.Len(); j++ {
+				child.Nodes = append(child.Nodes, dumpNode(value.Index(j)))
+			}
+			dumped.Children = append(dumped.Children, child)
+		case reflect.Map:
+			dumped.Children = append(dumped.Children, dumpedChild{
+				Name:  field.Name,
+				List:  true,
+				Nodes: dumpPairs(value),
+			})
+		case reflect.Interface, reflect.Pointer:
+			dumped.Children = append(dumped.Children, dumpedChild{
+				Name:  field.Name,
+				Nodes: []*dumpedNode{dumpNode(value)},
+			})
+		default:
+			dumped.Attrs = append(dumped.Attrs, dumpedAttr{Name: field.Name, Value: value.Interface()})
+		}
+	}
+	return dumped
+}
+
+// dumpPairs turns map of nodes into Pair nodes ordered by key position,
+// map iteration order is random and dumps must be stable
+func dumpPairs(m reflect.Value) []*dumpedNode {
+	pairs := []*dumpedNode{}
+	iter := m.MapRange()
+	for iter.Next() {
+		key, value := dumpNode(iter.Key()), dumpNode(iter.Value())
+		pair := &dumpedNode{
+			Type: "Pair",
+			Children: []dumpedChild{
+				{Name: "Key", Nodes: []*dumpedNode{key}},
+				{Name: "Value", Nodes: []*dumpedNode{value}},
+			},
+		}
+		if key != nil && value != nil {
+			pair.Pos, pair.End = key.Pos, value.End
+			// keys which are not nodes have no position, value has it
+			if !key.Pos.IsValid() {
+				pair.Pos = value.Pos
+			}
+		}
+		pairs = append(pairs, pair)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Pos.Offset < pairs[j].Pos.Offset
+	})
+	return pairs
+}
+
+// writeTree writes node header after the prefix and its children below,
+// indented by indent
+func (this *dumpedNode) writeTree(out io.Writer, prefix, indent string) {
+	if this == nil {
+		fmt.Fprintf(out, "%snil\n", prefix)
+		return
+	}
+
+	header := []string{this.Type}
+	for _, attr := range this.Attrs {
+		if s, ok := attr.Value.(string); ok {
+			header = append(header, fmt.Sprintf("%s=%q", attr.Name, s))
+		} else {
+			header = append(header, fmt.Sprintf("%s=%v", attr.Name, attr.Value))
+		}
+	}
+	if this.Pos.IsValid() {
+		header = append(header, lineColumn(this.Pos)+"-"+lineColumn(this.End))
+	}
+	fmt.Fprintf(out, "%s%s\n", prefix, strings.Join(header, " "))
+
+	childIndent := indent + "  "
+	for _, child := range this.Children {
+		if !child.List {
+			child.Nodes[0].writeTree(out, childIndent+child.Name+": ", childIndent)
+			continue
+		}
+		fmt.Fprintf(out, "%s%s: [%d]\n", childIndent, child.Name, len(child.Nodes))
+		for i, node := range child.Nodes {
+			node.writeTree(out, fmt.Sprintf("%s  [%d] ", childIndent, i), childIndent+"  ")
+		}
+	}
+}
+
+func (this *dumpedNode) toJSON() any {
+	if this == nil {
+		return nil
+	}
+
+	m := map[string]any{"type": this.Type}
+	if this.Pos.IsValid() {
+		m["start"] = toJSONPosition(this.Pos)
+		m["end"] = toJSONPosition(this.End)
+	}
+	for _, attr := range this.Attrs {
+		m[jsonName(attr.Name)] = attr.Value
+	}
+	for _, child := range this.Children {
+		if !child.List {
+			m[jsonName(child.Name)] = child.Nodes[0].toJSON()
+			continue
+		}
+		nodes := make([]any, 0, len(child.Nodes))
+		for _, node := range child.Nodes {
+			nodes = append(nodes, node.toJSON())
+		}
+		m[jsonName(child.Name)] = nodes
+	}
+	return m
+}
+
+// jsonName lowercases first letter of field name: ElseBlock -> elseBlock
+func jsonName(field string) string {
+	return strings.ToLower(field[:1]) + field[1:]
+}
+
+// inspect runs "tokens" or "ast" command: monkey tokens [-json] <file>.
+// Dump goes to stdout, errors to stderr and exit code is 1 when there were any
+func inspect(command string, args []string) {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print as JSON")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: monkey %s [-json] <file>\n", command)
+		flags.PrintDefaults()
+	}
+	// ExitOnError makes Parse exit by itself
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	path := flags.Arg(0)
+	content, err := toFilePath(path)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var errors []error
+	if command == "tokens" {
+		errors = DumpTokens(os.Stdout, path, content, *asJSON)
+	} else {
+		errors = DumpAst(os.Stdout, path, content, *asJSON)
+	}
+	if len(errors) > 0 {
+		printParserErrors(errors)
+		os.Exit(1)
+	}
+}
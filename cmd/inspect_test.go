@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"monkey/ast"
+)
+
+// go test ./cmd -update rewrites golden files with the current output
+var update = flag.Bool("update", false, "update golden files")
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestDumpGolden(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "inspect.mk"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		golden string
+		dump   func(out *bytes.Buffer) []error
+	}{
+		{"inspect.tokens.golden", func(out *bytes.Buffer) []error {
+			return DumpTokens(out, "inspect.mk", string(content), false)
+		}},
+		{"inspect.tokens.json.golden", func(out *bytes.Buffer) []error {
+			return DumpTokens(out, "inspect.mk", string(content), true)
+		}},
+		{"inspect.ast.golden", func(out *bytes.Buffer) []error {
+			return DumpAst(out, "inspect.mk", string(content), false)
+		}},
+		{"inspect.ast.json.golden", func(out *bytes.Buffer) []error {
+			return DumpAst(out, "inspect.mk", string(content), true)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			errors := tt.dump(out)
+			require.Empty(t, errors)
+			assertGolden(t, tt.golden, out.Bytes())
+		})
+	}
+}
+
+func TestDumpAstFnParameters(t *testing.T) {
+	content := "let f = fn(a, b = 10, ...rest) { a };"
+
+	out := &bytes.Buffer{}
+	require.Empty(t, DumpAst(out, "fn.mk", content, false))
+	assert.Equal(t, `Program 1:1-1:37
+  Statements: [1]
+    [0] LetStatement Constant=false 1:1-1:37
+      Identifier: Identifier Value="f" 1:5-1:6
+      Value: FnExpression 1:9-1:37
+        Arguments: [2]
+          [0] Identifier Value="a" 1:12-1:13
+          [1] Identifier Value="b" 1:15-1:16
+        Defaults: [2]
+          [0] nil
+          [1] IntLiteral Value=10 1:19-1:21
+        Rest: Identifier Value="rest" 1:26-1:30
+        Body: BlockExpression 1:32-1:37
+          Statements: [1]
+            [0] ExpressionStatement 1:34-1:35
+              Expression: Identifier Value="a" 1:34-1:35
+`, out.String())
+
+	out.Reset()
+	require.Empty(t, DumpAst(out, "fn.mk", content, true))
+	assert.Contains(t, out.String(), `"rest": {`)
+}
+
+func TestDumpErrors(t *testing.T) {
+	out := &bytes.Buffer{}
+	errors := DumpAst(out, "broken.mk", "let x = ;", false)
+	require.Len(t, errors, 1)
+	assert.True(t, strings.HasPrefix(out.String(), "Program"), out.String())
+
+	out.Reset()
+	errors = DumpTokens(out, "broken.mk", "'abc", false)
+	require.Len(t, errors, 1)
+	assert.Contains(t, out.String(), "EOF")
+}
+
+// notNodes has fields which dumpNode reaches but which are not nodes
+type notNodes struct {
+	Names  []string
+	Fields map[string]ast.Expression
+}
+
+func TestDumpNodeWithValuesWhichAreNotNodes(t *testing.T) {
+	value := notNodes{
+		Names:  []string{"a"},
+		Fields: map[string]ast.Expression{"b": nil},
+	}
+
+	out := &bytes.Buffer{}
+	dumpNode(reflect.ValueOf(value)).writeTree(out, "", "")
+	assert.Equal(t, `notNodes
+  Names: [1]
+    [0] string Value="a"
+  Fields: [1]
+    [0] Pair
+      Key: string Value="b"
+      Value: nil
+`, out.String())
+}
@@ -9,8 +9,8 @@ import (
 	"monkey/parser"
 )
 
-func Run(content string) {
-	l := lexer.New(content)
+func Run(filename, content string) {
+	l := lexer.NewFile(filename, content)
 	p := parser.New(l)
 	program := p.ParseProgram()
 
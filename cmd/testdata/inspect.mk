@@ -0,0 +1,6 @@
+// hashes, functions and interpolation
+let person = #{"name": "Monkey", "age": 1};
+/* block comment */
+let greet = fn(p) { "hi ${p["name"]}!" };
+greet(person);
+if (person["age"] > 1) { "old" } else if (person["age"] == 1) { "one" } else { "new" };
@@ -0,0 +1,154 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalAssignment handles '=' and compound assignments like '+=' to
+// a variable, to an element of array or hash or to a field of hash. 'x += 1' is 'x = x + 1'
+// with the target evaluated only once. Arrays and hashes are changed in
+// place, so every variable referring to the same array or hash sees the
+// change. Assignment evaluates to the assigned value
+func evalAssignment(scope *object.Scope, node *ast.AssignExpression) object.Object {
+	// operator of compound assignment: '+=' -> '+', empty for '='
+	operator := strings.TrimSuffix(node.Operator, "=")
+
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		current, isDefined := scope.Get(target.Value)
+		if !isDefined {
+			return &object.ErrorObject{
+				Message: &object.StringObject{Value: fmt.Sprintf("%s is not defined", target.Value)},
+			}
+		}
+		if pos, isConst := scope.Constant(target.Value); isConst {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("cannot assign to constant %s declared at %s", target.Value, pos),
+				},
+			}
+		}
+		value := evalAssignedValue(scope, operator, current, node.Value)
+		if isInterrupted(value) {
+			return value
+		}
+		scope.Set(target.Value, value)
+		return value
+	case *ast.IndexExpression:
+		return evalIndexAssignment(scope, target, operator, node.Value)
+	case *ast.MemberExpression:
+		return evalMemberAssignment(scope, target, operator, node.Value)
+	default:
+		// parser accepts only the targets above
+		return &object.ErrorObject{
+			Message: &object.StringObject{Value: fmt.Sprintf("cannot assign to %s", node.Target.String())},
+		}
+	}
+}
+
+// evalAssignedValue evaluates right side of assignment, for compound
+// assignment it is combined with the current value of the target
+func evalAssignedValue(
+	scope *object.Scope,
+	operator string,
+	current object.Object,
+	right ast.Expression,
+) object.Object {
+	value := Eval(scope, right)
+	if isInterrupted(value) || operator == "" {
+		return value
+	}
+	return evalInfixExpression(operator, current, value)
+}
+
+// evalIndexAssignment replaces array element or sets hash entry. Array
+// index must be within the array, hashes get new keys when needed.
+// Frozen arrays and hashes cannot be changed
+func evalIndexAssignment(
+	scope *object.Scope,
+	target *ast.IndexExpression,
+	operator string,
+	right ast.Expression,
+) object.Object {
+	collection := Eval(scope, target.Identifier)
+	if isInterrupted(collection) {
+		return collection
+	}
+	index := Eval(scope, target.IndexExpression)
+	if isInterrupted(index) {
+		return index
+	}
+
+	switch collection := collection.(type) {
+	case *object.ArrayObject:
+		if collection.Frozen {
+			return makeFrozenError(collection)
+		}
+		intIndex, isInt := index.(*object.IntObject)
+		if !isInt {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("index must be INT, but was %s", index.Type()),
+				},
+			}
+		}
+		position, inBounds := elementIndex(intIndex.Value, int64(len(collection.Items)))
+		if !inBounds {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf(
+						"index %d out of bounds for array of length %d",
+						intIndex.Value,
+						len(collection.Items),
+					),
+				},
+			}
+		}
+		value := evalAssignedValue(scope, operator, collection.Items[position], right)
+		if isInterrupted(value) {
+			return value
+		}
+		collection.Items[position] = value
+		return value
+	case *object.HashObject:
+		if collection.Frozen {
+			return makeFrozenError(collection)
+		}
+		key, isKey := hashKey(index)
+		if !isKey {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("index must be [STRING, INT, BOOL], but was %s", index.Type()),
+				},
+			}
+		}
+		current, found := collection.Map[key]
+		if !found {
+			current = object.NULL_OBJECT
+		}
+		value := evalAssignedValue(scope, operator, current, right)
+		if isInterrupted(value) {
+			return value
+		}
+		collection.Map[key] = value
+		return value
+	default:
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("cannot assign to element of %s", collection.Type()),
+			},
+		}
+	}
+}
+
+func makeFrozenError(collection object.Object) *object.ErrorObject {
+	return &object.ErrorObject{
+		Message: &object.StringObject{
+			Value: fmt.Sprintf("cannot assign to element of frozen %s", collection.Type()),
+		},
+	}
+}
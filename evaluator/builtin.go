@@ -2,7 +2,12 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"monkey/object"
 )
@@ -13,14 +18,7 @@ var builtins = map[string]object.BuiltinFnObject{
 		Function: func(args ...object.Object) object.Object {
 			rawArgs := []any{}
 			for _, a := range args {
-				if !isOneOfTypes(
-					a,
-					object.STRING,
-					object.INT,
-					object.BOOL,
-					object.ARRAY,
-					object.HASH,
-				) {
+				if !isPrintable(a) {
 					return &object.ErrorObject{
 						Message: &object.StringObject{
 							Value: fmt.Sprintf("cannot call 'puts' on %s", a.Type()),
@@ -106,14 +104,17 @@ var builtins = map[string]object.BuiltinFnObject{
 
 			switch arg := args[0].(type) {
 			case *object.StringObject:
-				return &object.IntObject{Value: int64(len(arg.Value))}
+				// characters, same as indexing counts them
+				return &object.IntObject{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *object.ArrayObject:
 				return &object.IntObject{Value: int64(len(arg.Items))}
+			case *object.RangeObject:
+				return &object.IntObject{Value: arg.Len()}
 			default:
 				return &object.ErrorObject{
 					Message: &object.StringObject{
 						Value: fmt.Sprintf(
-							"'len' accepts only STRING or ARRAY arguments, but was %s: ",
+							"'len' accepts only STRING, ARRAY or RANGE arguments, but was %s: ",
 							args[0].Type(),
 						),
 					},
@@ -121,6 +122,126 @@ var builtins = map[string]object.BuiltinFnObject{
 			}
 		},
 	},
+	"int": {
+		Name: "int",
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'int' requires exactly one argument, but had %d",
+							len(args),
+						),
+					},
+				}
+			}
+			switch arg := args[0].(type) {
+			case *object.IntObject:
+				return arg
+			case *object.FloatObject:
+				// float is truncated towards zero, like Go conversion does
+				if math.IsNaN(arg.Value) || arg.Value >= math.MaxInt64 || arg.Value < math.MinInt64 {
+					return &object.ErrorObject{
+						Message: &object.StringObject{
+							Value: fmt.Sprintf("'int': %s is out of INT range", arg.Inspect()),
+						},
+					}
+				}
+				return &object.IntObject{Value: int64(arg.Value)}
+			case *object.StringObject:
+				value, err := strconv.ParseInt(strings.TrimSpace(arg.Value), 10, 64)
+				if err != nil {
+					return &object.ErrorObject{
+						Message: &object.StringObject{
+							Value: fmt.Sprintf("'int': cannot convert %q to INT", arg.Value),
+						},
+					}
+				}
+				return &object.IntObject{Value: value}
+			default:
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'int' accepts only INT, FLOAT or STRING argument, but was %s",
+							args[0].Type(),
+						),
+					},
+				}
+			}
+		},
+	},
+	"float": {
+		Name: "float",
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'float' requires exactly one argument, but had %d",
+							len(args),
+						),
+					},
+				}
+			}
+			switch arg := args[0].(type) {
+			case *object.IntObject:
+				return &object.FloatObject{Value: float64(arg.Value)}
+			case *object.FloatObject:
+				return arg
+			case *object.StringObject:
+				value, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64)
+				if err != nil {
+					return &object.ErrorObject{
+						Message: &object.StringObject{
+							Value: fmt.Sprintf("'float': cannot convert %q to FLOAT", arg.Value),
+						},
+					}
+				}
+				return &object.FloatObject{Value: value}
+			default:
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'float' accepts only INT, FLOAT or STRING argument, but was %s",
+							args[0].Type(),
+						),
+					},
+				}
+			}
+		},
+	},
+	"array": {
+		Name: "array",
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'array' requires exactly one argument, but had %d",
+							len(args),
+						),
+					},
+				}
+			}
+			if !isOneOfTypes(args[0], object.ARRAY, object.RANGE, object.STRING) {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'array' accepts only ARRAY, RANGE or STRING argument, but was %s",
+							args[0].Type(),
+						),
+					},
+				}
+			}
+			// new array with items of array or range, or characters of string
+			items := []object.Object{}
+			iterate(args[0], func(_, value object.Object) bool {
+				items = append(items, value)
+				return true
+			})
+			return &object.ArrayObject{Items: items}
+		},
+	},
 	"first": {
 		Name: "first",
 		Function: func(args ...object.Object) object.Object {
@@ -243,4 +364,125 @@ var builtins = map[string]object.BuiltinFnObject{
 			return &object.ArrayObject{Items: newItems}
 		},
 	},
+	"freeze": {
+		Name: "freeze",
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf(
+							"'freeze' requires exactly one argument, but had %d",
+							len(args),
+						),
+					},
+				}
+			}
+			freezeObject(args[0])
+			return args[0]
+		},
+	},
+}
+
+func init() {
+	// these call functions back through Eval, which uses builtins, so
+	// they cannot be in builtins initializer without initialization cycle
+	builtins["map"] = object.BuiltinFnObject{
+		Name: "map",
+		Function: func(args ...object.Object) object.Object {
+			items := []object.Object{}
+			err := applyToItems("map", args, func(_, result object.Object) {
+				items = append(items, result)
+			})
+			if err != nil {
+				return err
+			}
+			return &object.ArrayObject{Items: items}
+		},
+	}
+	builtins["filter"] = object.BuiltinFnObject{
+		Name: "filter",
+		Function: func(args ...object.Object) object.Object {
+			items := []object.Object{}
+			err := applyToItems("filter", args, func(item, result object.Object) {
+				if convertToBoolish(result) {
+					items = append(items, item)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			return &object.ArrayObject{Items: items}
+		},
+	}
+}
+
+// applyToItems checks arguments of 'map' like builtin: array or range and
+// function, then calls the function on every item and passes the item and
+// the result to visit
+func applyToItems(
+	name string,
+	args []object.Object,
+	visit func(item, result object.Object),
+) *object.ErrorObject {
+	if len(args) != 2 {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("'%s' requires exactly two arguments, but had %d", name, len(args)),
+			},
+		}
+	}
+	if !isOneOfTypes(args[0], object.ARRAY, object.RANGE) {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf(
+					"'%s' first argument must be ARRAY or RANGE, but was %s",
+					name,
+					args[0].Type(),
+				),
+			},
+		}
+	}
+	if !isOneOfTypes(args[1], object.FN, object.BUILTIN_FN) {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf(
+					"'%s' second argument must be FN or BUILTIN_FN, but was %s",
+					name,
+					args[1].Type(),
+				),
+			},
+		}
+	}
+
+	var err *object.ErrorObject
+	iterate(args[0], func(_, item object.Object) bool {
+		// callback has no name at the call, errors use its own name
+		result := applyFunction("", args[1], []object.Object{item})
+		if errObj, isErr := result.(*object.ErrorObject); isErr {
+			err = errObj
+			return false
+		}
+		visit(item, result)
+		return true
+	})
+	return err
+}
+
+// methods lists builtins which can be called as methods of values of the
+// type, receiver is passed as the first argument: "abc".len() is len("abc")
+var methods = map[object.ObjectType][]string{
+	object.STRING: {"len", "array", "int", "float"},
+	object.ARRAY:  {"len", "array", "first", "last", "rest", "push", "map", "filter", "freeze"},
+	object.HASH:   {"freeze"},
+	object.RANGE:  {"len", "array", "map", "filter"},
+	object.INT:    {"int", "float"},
+	object.FLOAT:  {"int", "float"},
+}
+
+func lookupMethod(receiverType object.ObjectType, name string) (*object.BuiltinFnObject, bool) {
+	if !slices.Contains(methods[receiverType], name) {
+		return nil, false
+	}
+	builtin := builtins[name]
+	return &builtin, true
 }
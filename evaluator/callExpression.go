@@ -0,0 +1,246 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalCallExpression calls function with leading values followed by
+// the arguments, leading values come from pipe: x |> f(a) is f(x, a)
+func evalCallExpression(
+	scope *object.Scope,
+	node *ast.CallExpression,
+	leading ...object.Object,
+) object.Object {
+	argumentValues, err := evalArguments(scope, node.Arguments)
+	if err != nil {
+		return err
+	}
+	argumentValues = append(leading, argumentValues...)
+
+	calleeObj := Eval(scope, node.FnIdentifier)
+	if isInterrupted(calleeObj) {
+		return calleeObj
+	}
+	if !isOneOfTypes(calleeObj, object.FN, object.BUILTIN_FN) {
+		return makeNotFunctionError(node.FnIdentifier.String(), calleeObj)
+	}
+	return applyFunction(calleeName(node.FnIdentifier), calleeObj, argumentValues)
+}
+
+// evalMethodCallExpression calls function stored in hash field, or
+// builtin from methods table of the receiver type with receiver as the
+// first argument. Leading values from pipe go before the arguments
+func evalMethodCallExpression(
+	scope *object.Scope,
+	node *ast.MethodCallExpression,
+	leading ...object.Object,
+) object.Object {
+	receiver := Eval(scope, node.Receiver)
+	if isInterrupted(receiver) {
+		return receiver
+	}
+	if node.Optional && isType(object.NULL, receiver) {
+		return object.NULL_OBJECT
+	}
+	argumentValues, err := evalArguments(scope, node.Arguments)
+	if err != nil {
+		return err
+	}
+	argumentValues = append(leading, argumentValues...)
+
+	if hash, isHash := receiver.(*object.HashObject); isHash {
+		if field, found := hash.Map[node.Method.Value]; found {
+			if !isOneOfTypes(field, object.FN, object.BUILTIN_FN) {
+				return makeNotFunctionError(node.Receiver.String()+"."+node.Method.Value, field)
+			}
+			return applyFunction(node.Receiver.String()+"."+node.Method.Value, field, argumentValues)
+		}
+	}
+
+	method, found := lookupMethod(receiver.Type(), node.Method.Value)
+	if !found {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("%s has no method %s", receiver.Type(), node.Method.Value),
+			},
+			Pos: node.Method.Pos(),
+		}
+	}
+	return applyFunction(node.Method.Value, method, append([]object.Object{receiver}, argumentValues...))
+}
+
+// evalPipeExpression passes left value as the first argument to the call
+// on the right: x |> f(a) is f(x, a). When the right side is not a call
+// it must evaluate to a function which gets the value alone: x |> f is f(x)
+func evalPipeExpression(scope *object.Scope, node *ast.InfixExpression) object.Object {
+	value := Eval(scope, node.Left)
+	if isInterrupted(value) {
+		return value
+	}
+
+	var result object.Object
+	switch right := node.Right.(type) {
+	case *ast.CallExpression:
+		result = evalCallExpression(scope, right, value)
+	case *ast.MethodCallExpression:
+		result = evalMethodCallExpression(scope, right, value)
+	default:
+		fn := Eval(scope, right)
+		if isInterrupted(fn) {
+			return fn
+		}
+		if !isOneOfTypes(fn, object.FN, object.BUILTIN_FN) {
+			return makeNotFunctionError(right.String(), fn)
+		}
+		result = applyFunction(calleeName(right), fn, []object.Object{value})
+	}
+	// errors of the call are positioned at the call, not at the pipe
+	if errObj, isErr := result.(*object.ErrorObject); isErr && !errObj.Pos.IsValid() {
+		errObj.Pos = node.Right.Pos()
+	}
+	return result
+}
+
+// evalArguments evaluates arguments in order, the second result is set
+// when one of them is an error or break, continue or return
+func evalArguments(scope *object.Scope, arguments []ast.Expression) ([]object.Object, object.Object) {
+	values := []object.Object{}
+	for _, a := range arguments {
+		value := Eval(scope, a)
+		if isInterrupted(value) {
+			return nil, value
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// calleeName is the name function is called by in errors about arguments,
+// it is empty when function is not called by a name, like 'fn(x) { x }(1)'
+func calleeName(callee ast.Expression) string {
+	switch callee.(type) {
+	case *ast.Identifier, *ast.MemberExpression:
+		return callee.String()
+	default:
+		return ""
+	}
+}
+
+// applyFunction calls user function or builtin, fn must be one of them.
+// name is how the function is called, errors about arguments use it. When
+// it is empty they use name of the function itself, if it has one
+func applyFunction(name string, fn object.Object, argumentValues []object.Object) object.Object {
+	if builtinFn, isBuiltin := fn.(*object.BuiltinFnObject); isBuiltin {
+		return builtinFn.Function(argumentValues...)
+	}
+	fnObject := fn.(*object.FnObject)
+
+	// create new scope and populate it with arguments
+	inner := fnObject.LexicalScope.Spawn()
+	if err := bindArguments(name, inner, fnObject, argumentValues); err != nil {
+		return err
+	}
+
+	var result object.Object = object.NULL_OBJECT
+	for i := range fnObject.Body.Statements {
+		result = Eval(inner, fnObject.Body.Statements[i])
+		if isOneOfTypes(result, object.ERROR) {
+			return result
+		}
+		if isOneOfTypes(result, object.RETURN) {
+			return result.(*object.ReturnObject).Value
+		}
+	}
+	return result
+}
+
+// bindArguments adds arguments of the call to its scope. Missing ones get
+// default values, which are evaluated in that scope, so they can use the
+// preceding arguments. Extra ones are collected into rest parameter
+func bindArguments(
+	name string,
+	scope *object.Scope,
+	fn *object.FnObject,
+	argumentValues []object.Object,
+) *object.ErrorObject {
+	// parameters with default values go after the required ones
+	required := 0
+	for required < len(fn.Arguments) && defaultValue(fn, required) == nil {
+		required++
+	}
+	if len(argumentValues) < required || (fn.Rest == nil && len(argumentValues) > len(fn.Arguments)) {
+		return makeArityError(name, fn, required, len(argumentValues))
+	}
+
+	for i, argument := range fn.Arguments {
+		if i < len(argumentValues) {
+			scope.Add(argument.Value, argumentValues[i])
+			continue
+		}
+		value := Eval(scope, defaultValue(fn, i))
+		if errObj, isErr := value.(*object.ErrorObject); isErr {
+			return errObj
+		}
+		scope.Add(argument.Value, value)
+	}
+
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(argumentValues) > len(fn.Arguments) {
+			rest = append(rest, argumentValues[len(fn.Arguments):]...)
+		}
+		scope.Add(fn.Rest.Value, &object.ArrayObject{Items: rest})
+	}
+	return nil
+}
+
+// defaultValue returns default value of i-th argument or nil
+func defaultValue(fn *object.FnObject, i int) ast.Expression {
+	if i < len(fn.Defaults) {
+		return fn.Defaults[i]
+	}
+	return nil
+}
+
+func makeArityError(name string, fn *object.FnObject, required int, had int) *object.ErrorObject {
+	if name == "" {
+		name = fn.Name
+	}
+	function := fmt.Sprintf("'%s'", name)
+	if name == "" {
+		function = "anonymous function"
+	}
+
+	var expected string
+	switch {
+	case fn.Rest != nil:
+		expected = fmt.Sprintf("at least %s", argumentsCount(required))
+	case required < len(fn.Arguments):
+		expected = fmt.Sprintf("from %d to %s", required, argumentsCount(len(fn.Arguments)))
+	default:
+		expected = fmt.Sprintf("exactly %s", argumentsCount(required))
+	}
+	return &object.ErrorObject{
+		Message: &object.StringObject{
+			Value: fmt.Sprintf("%s requires %s, but had %d", function, expected, had),
+		},
+	}
+}
+
+func argumentsCount(count int) string {
+	if count == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", count)
+}
+
+func makeNotFunctionError(name string, value object.Object) *object.ErrorObject {
+	return &object.ErrorObject{
+		Message: &object.StringObject{
+			Value: fmt.Sprintf("'%s' is not a function, got %s", name, value.Type()),
+		},
+	}
+}
@@ -9,10 +9,19 @@ import (
 )
 
 func Eval(scope *object.Scope, node ast.Node) object.Object {
+	result := eval(scope, node)
+	// errors are positioned by the innermost node they went through
+	if errObj, isErr := result.(*object.ErrorObject); isErr && node != nil && !errObj.Pos.IsValid() {
+		errObj.Pos = node.Pos()
+	}
+	return result
+}
+
+func eval(scope *object.Scope, node ast.Node) object.Object {
 	switch node := node.(type) {
 
 	case *ast.Program:
-		var result object.Object
+		var result object.Object = object.NULL_OBJECT
 		for i := range node.Statements {
 			result = Eval(scope, node.Statements[i])
 
@@ -24,29 +33,35 @@ func Eval(scope *object.Scope, node ast.Node) object.Object {
 
 	// Expressions
 	case *ast.ExpressionStatement:
-		result := Eval(scope, node.Expression)
-		return resolveIdentIfNeeded(scope, result)
+		return Eval(scope, node.Expression)
 	case *ast.PrefixExpression:
 		operator := node.Operator
 		obj := Eval(scope, node.Value)
-		obj = resolveIdentIfNeeded(scope, obj)
 
-		if isType(object.ERROR, obj) {
+		if isInterrupted(obj) {
 			return obj
 		}
 		return evalPrefixExpression(operator, obj)
 	case *ast.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" || node.Operator == "??" {
+			return evalLogicalExpression(scope, node)
+		}
+		if node.Operator == "|>" {
+			return evalPipeExpression(scope, node)
+		}
 		leftObj := Eval(scope, node.Left)
 
-		if isType(object.ERROR, leftObj) {
+		if isInterrupted(leftObj) {
 			return leftObj
 		}
 		operator := node.Operator
 		rightObj := Eval(scope, node.Right)
-		if isType(object.ERROR, rightObj) {
+		if isInterrupted(rightObj) {
 			return rightObj
 		}
-		return evalInfixExpression(scope, operator, leftObj, rightObj)
+		return evalInfixExpression(operator, leftObj, rightObj)
+	case *ast.AssignExpression:
+		return evalAssignment(scope, node)
 	case *ast.IfExpression:
 		return evalIfExpression(scope, node)
 	case *ast.BlockExpression:
@@ -54,69 +69,20 @@ func Eval(scope *object.Scope, node ast.Node) object.Object {
 		var result object.Object = object.NULL_OBJECT
 		for i := range node.Statements {
 			result = Eval(inner, node.Statements[i])
-			if isOneOfTypes(result, object.ERROR, object.RETURN) {
+			if isOneOfTypes(result, object.ERROR, object.RETURN, object.BREAK, object.CONTINUE) {
 				return result
 			}
 		}
 		return result
 	case *ast.CallExpression:
-		// resolve argumentValues
-		argumentValues := []object.Object{}
-		for _, a := range node.Arguments {
-			argVal := Eval(scope, a)
-			argVal = resolveIdentIfNeeded(scope, argVal)
-			if isType(object.ERROR, argVal) {
-				return argVal
-			}
-			argumentValues = append(argumentValues, argVal)
-		}
-
-		if builtinFn, isThere := builtins[node.FnIdentifier.String()]; isThere {
-			return builtinFn.Function(argumentValues...)
-		}
-
-		calleeObj := Eval(scope, node.FnIdentifier)
-		calleeObj = resolveIdentIfNeeded(scope, calleeObj)
-		if isType(object.ERROR, calleeObj) {
-			return calleeObj
-		}
-
-		fnObject, isFnObject := calleeObj.(*object.FnObject)
-		if !isFnObject {
-			return &object.ErrorObject{
-				Message: &object.StringObject{
-					Value: fmt.Sprintf(
-						"'%s' is not a function, got %s",
-						node.FnIdentifier.String(),
-						calleeObj.Type(),
-					),
-				},
-			}
-		}
-
-		// create new scope and populate it with arguments
-		inner := fnObject.LexicalScope.Spawn()
-		for i := 0; i < len(fnObject.Arguments); i++ {
-			inner.Add(fnObject.Arguments[i].Value, argumentValues[i])
-		}
-
-		var result object.Object = object.NULL_OBJECT
-		for i := range fnObject.Body.Statements {
-			result = Eval(inner, fnObject.Body.Statements[i])
-			if isOneOfTypes(result, object.ERROR) {
-				return result
-			}
-			if isOneOfTypes(result, object.RETURN) {
-				return result.(*object.ReturnObject).Value
-			}
-		}
-		return result
+		return evalCallExpression(scope, node)
+	case *ast.MethodCallExpression:
+		return evalMethodCallExpression(scope, node)
 	case *ast.ArrayExpression:
 		objects := []object.Object{}
 		for _, a := range node.Elements {
 			obj := Eval(scope, a)
-			obj = resolveIdentIfNeeded(scope, obj)
-			if isType(object.ERROR, obj) {
+			if isInterrupted(obj) {
 				return obj
 			}
 			objects = append(objects, obj)
@@ -127,8 +93,7 @@ func Eval(scope *object.Scope, node ast.Node) object.Object {
 
 		for key, val := range node.Map {
 			keyObject := Eval(scope, key)
-			keyObject = resolveIdentIfNeeded(scope, keyObject)
-			if isType(object.ERROR, keyObject) {
+			if isInterrupted(keyObject) {
 				return keyObject
 			}
 			if !isOneOfTypes(keyObject, object.STRING, object.INT, object.BOOL) {
@@ -151,8 +116,7 @@ func Eval(scope *object.Scope, node ast.Node) object.Object {
 			}
 
 			valObject := Eval(scope, val)
-			valObject = resolveIdentIfNeeded(scope, valObject)
-			if isType(object.ERROR, valObject) {
+			if isInterrupted(valObject) {
 				return valObject
 			}
 
@@ -175,39 +139,113 @@ func Eval(scope *object.Scope, node ast.Node) object.Object {
 
 	case *ast.IndexExpression:
 		return evalIndexExpression(scope, node)
+	case *ast.SliceExpression:
+		return evalSliceExpression(scope, node)
+	case *ast.MemberExpression:
+		return evalMemberExpression(scope, node)
+	case *ast.RangeExpression:
+		return evalRangeExpression(scope, node)
 
 	case *ast.IntLiteral:
 		return &object.IntObject{Value: node.Value}
+	case *ast.FloatLiteral:
+		return &object.FloatObject{Value: node.Value}
 	case *ast.BoolLiteral:
 		if node.Value {
 			return &object.TRUE_OBJECT
 		} else {
 			return &object.FALSE_OBJECT
 		}
+	case *ast.NullLiteral:
+		return object.NULL_OBJECT
 	case *ast.StringLiteral:
 		return &object.StringObject{Value: node.Value}
+	case *ast.InterpolatedString:
+		sb := strings.Builder{}
+		for i, text := range node.Texts {
+			sb.WriteString(text.Value)
+			if i >= len(node.Expressions) {
+				continue
+			}
+			obj := Eval(scope, node.Expressions[i])
+			if isInterrupted(obj) {
+				return obj
+			}
+			// same as 'puts' prints it
+			if !isPrintable(obj) {
+				return &object.ErrorObject{
+					Message: &object.StringObject{
+						Value: fmt.Sprintf("cannot interpolate %s into string", obj.Type()),
+					},
+					Pos: node.Expressions[i].Pos(),
+				}
+			}
+			sb.WriteString(obj.Inspect())
+		}
+		return &object.StringObject{Value: sb.String()}
 	case *ast.Identifier:
-		return &object.IdentifierObject{Value: node.Value}
+		value, found := scope.Get(node.Value)
+		if !found {
+			// builtins are looked up last, so variables can shadow them
+			if builtin, isBuiltin := builtins[node.Value]; isBuiltin {
+				return &builtin
+			}
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("identifier %s not found", node.Value),
+				},
+			}
+		}
+		return value
 	case *ast.FnExpression:
-		return &object.FnObject{Arguments: node.Arguments, Body: node.Body, LexicalScope: scope}
+		return &object.FnObject{
+			Arguments:    node.Arguments,
+			Defaults:     node.Defaults,
+			Rest:         node.Rest,
+			Body:         node.Body,
+			LexicalScope: scope,
+		}
 
 	// Statements
 	case *ast.ReturnStatement:
 		result := Eval(scope, node.Value)
-		result = resolveIdentIfNeeded(scope, result)
-		if isType(object.ERROR, result) {
+		if isInterrupted(result) {
 			return result
 		}
 		return &object.ReturnObject{Value: result}
 
+	case *ast.WhileStatement:
+		return evalWhileStatement(scope, node)
+	case *ast.ForStatement:
+		return evalForStatement(scope, node)
+	case *ast.BreakStatement:
+		return object.BREAK_OBJECT
+	case *ast.ContinueStatement:
+		return object.CONTINUE_OBJECT
+
 	case *ast.LetStatement:
 		ident := node.Identifier.Value
+		if pos, isConst := scope.LocalConstant(ident); isConst {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("%s is already declared as constant at %s", ident, pos),
+				},
+				Pos: node.Identifier.Pos(),
+			}
+		}
 		val := Eval(scope, node.Value)
-		val = resolveIdentIfNeeded(scope, val)
-		if isType(object.ERROR, val) {
+		if isInterrupted(val) {
 			return val
 		}
-		scope.Add(ident, val)
+		if fn, isFn := val.(*object.FnObject); isFn && fn.Name == "" {
+			// 'let add = fn...' names the function for error messages
+			fn.Name = ident
+		}
+		if node.Constant {
+			scope.AddConst(ident, val, node.Identifier.Pos())
+		} else {
+			scope.Add(ident, val)
+		}
 		return val
 
 	default:
@@ -74,6 +74,11 @@ func TestStringLiteralEvaluation(t *testing.T) {
 		{"'hello world';", "hello world"},
 		{"'';", ""},
 		{"'hello \\'mom\\'';", "hello 'mom'"},
+		{"'привет, 世界 😀';", "привет, 世界 😀"},
+		{"'cafe\u0301';", "cafe\u0301"},
+		{`"line\n\ttab";`, "line\n\ttab"},
+		{`"\u{1F648}\x21";`, "🙈!"},
+		{"`raw\\n`;", "raw\\n"},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +182,116 @@ func TestInfixArithmeticEvaluation(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Float Tests
+// =============================================================================
+
+func TestFloatEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"-2.5;", -2.5},
+		{"1.5 + 1.5;", 3},
+		{"1.5 + 1;", 2.5},
+		{"1 + 1.5;", 2.5},
+		{"10 - 0.5;", 9.5},
+		{"2 * 1.25;", 2.5},
+		{"1 / 4.0;", 0.25},
+		{"7.0 / 2;", 3.5},
+		{"let price = 9.99; let qty = 3; price * qty;", 29.97},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.FloatObject{}, result)
+			assert.InDelta(t, tt.expected, result.(*object.FloatObject).Value, 1e-9)
+		})
+	}
+
+	boolTests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1.5 < 2;", true},
+		{"2 > 1.5;", true},
+		{"1.0 == 1;", true},
+		{"1 != 1.0;", false},
+		{"0.1 + 0.2 == 0.3;", false},
+		{"!0.0;", true},
+		{"!0.5;", false},
+	}
+
+	for _, tt := range boolTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.BoolObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.BoolObject).Value)
+		})
+	}
+
+	t.Run("int division truncates", func(t *testing.T) {
+		result := evaluate("7 / 2;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(3), result.(*object.IntObject).Value)
+	})
+
+	t.Run("int division by zero is an error", func(t *testing.T) {
+		assertError(t, evaluate("1 / 0;"), "integer division by zero")
+	})
+
+	t.Run("float division by zero is infinity", func(t *testing.T) {
+		result := evaluate("1.0 / 0;")
+		require.IsType(t, &object.FloatObject{}, result)
+		assert.Equal(t, "+Inf", result.Inspect())
+	})
+
+	t.Run("inspect keeps fraction", func(t *testing.T) {
+		assert.Equal(t, "3.0", evaluate("1.5 * 2;").Inspect())
+		assert.Equal(t, "1e+21", evaluate("1e21;").Inspect())
+	})
+
+	t.Run("string coercion", func(t *testing.T) {
+		result := evaluate("'price: ' + 2.5;")
+		require.IsType(t, &object.StringObject{}, result)
+		assert.Equal(t, "price: 2.5", result.(*object.StringObject).Value)
+	})
+
+	t.Run("float with bool is an error", func(t *testing.T) {
+		assertError(t, evaluate("1.5 + true;"), "cannot perform operation 'FLOAT + BOOL'")
+	})
+}
+
+func TestNumberConversionBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected object.Object
+	}{
+		{"int(3.99);", &object.IntObject{Value: 3}},
+		{"int(-3.99);", &object.IntObject{Value: -3}},
+		{"int(5);", &object.IntObject{Value: 5}},
+		{"int(' 42 ');", &object.IntObject{Value: 42}},
+		{"float(3);", &object.FloatObject{Value: 3}},
+		{"float(2.5);", &object.FloatObject{Value: 2.5}},
+		{"float('1e3');", &object.FloatObject{Value: 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, evaluate(tt.input))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		assertError(t, evaluate("int('abc');"), `'int': cannot convert "abc" to INT`)
+		assertError(t, evaluate("int(1e30);"), "out of INT range")
+		assertError(t, evaluate("float(true);"), "'float' accepts only INT, FLOAT or STRING argument, but was BOOL")
+		assertError(t, evaluate("int(1, 2);"), "exactly one argument")
+	})
+}
+
 // =============================================================================
 // Infix Comparison Tests
 // =============================================================================
@@ -241,6 +356,39 @@ func TestStringConcatenation(t *testing.T) {
 	}
 }
 
+func TestStringInterpolation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let n = 3; let total = 10; "count: ${n} of ${total}";`, "count: 3 of 10"},
+		{`"${1 + 2}${'!' * 3}";`, "3!!!"},
+		{`"price: ${2.5}, ok: ${true}";`, "price: 2.5, ok: true"},
+		{`"items: ${[1, 2]}";`, "items: [1, 2]"},
+		{`let name = "monkey"; "hello ${"dear ${name}"}";`, "hello dear monkey"},
+		{`let f = fn(x) { x * 2 }; "${f(21)}";`, "42"},
+		{`"\${not interpolated}";`, "${not interpolated}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.StringObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.StringObject).Value)
+		})
+	}
+
+	t.Run("non printable value is an error", func(t *testing.T) {
+		result := evaluate(`"fn: ${fn() {}}";`)
+		assertError(t, result, "cannot interpolate FN into string")
+		assert.Equal(t, "1:8", result.(*object.ErrorObject).Pos.String())
+	})
+
+	t.Run("error inside interpolation propagates", func(t *testing.T) {
+		assertError(t, evaluate(`"${missing}";`), "identifier missing not found")
+	})
+}
+
 func TestStringMultiplication(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -322,16 +470,16 @@ func TestLogicalOperatorEvaluation(t *testing.T) {
 		{"false || true;", true},
 		{"false || false;", false},
 
-		// Truthiness with integers
-		{"5 && 3;", true},  // both truthy
-		{"5 && 0;", false}, // 0 is falsy
-		{"5 || 0;", true},  // 5 is truthy
-
 		// Combined with NOT
 		{"!false && true;", true},
 		{"!true || false;", false},
 		{"!(true && false);", true},
 		{"!(false || false);", true},
+
+		// Lower precedence than comparisons
+		{"1 == 1 && 2 == 2;", true},
+		{"1 == 2 || 2 < 3;", true},
+		{"true || false && false;", true},
 	}
 
 	for _, tt := range tests {
@@ -341,6 +489,71 @@ func TestLogicalOperatorEvaluation(t *testing.T) {
 			assert.Equal(t, tt.expected, result.(*object.BoolObject).Value)
 		})
 	}
+
+	// the operand deciding the result is returned as is
+	operandTests := []struct {
+		input    string
+		expected any
+	}{
+		{"5 && 3;", int64(3)},
+		{"5 && 0;", int64(0)},
+		{"0 && 5;", int64(0)},
+		{"5 || 0;", int64(5)},
+		{"0 || 5;", int64(5)},
+		{"'' || 'default';", "default"},
+		{"'name' || 'default';", "name"},
+		{"'a' && 'b';", "b"},
+	}
+
+	for _, tt := range operandTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			switch expected := tt.expected.(type) {
+			case int64:
+				require.IsType(t, &object.IntObject{}, result)
+				assert.Equal(t, expected, result.(*object.IntObject).Value)
+			case string:
+				require.IsType(t, &object.StringObject{}, result)
+				assert.Equal(t, expected, result.(*object.StringObject).Value)
+			}
+		})
+	}
+}
+
+func TestLogicalOperatorShortCircuit(t *testing.T) {
+	t.Run("right side of && is skipped", func(t *testing.T) {
+		result := evaluate("let calls = 0; let f = fn() { calls = calls + 1; true }; false && f(); calls;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(0), result.(*object.IntObject).Value)
+	})
+
+	t.Run("right side of || is skipped", func(t *testing.T) {
+		result := evaluate("let calls = 0; let f = fn() { calls = calls + 1; true }; true || f(); calls;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(0), result.(*object.IntObject).Value)
+	})
+
+	t.Run("right side is evaluated when needed", func(t *testing.T) {
+		result := evaluate("let calls = 0; let f = fn() { calls = calls + 1; true }; true && f(); false || f(); calls;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(2), result.(*object.IntObject).Value)
+	})
+
+	t.Run("guard protects from error", func(t *testing.T) {
+		result := evaluate("let arr = []; len(arr) > 0 && arr[0] == 1;")
+		require.IsType(t, &object.BoolObject{}, result)
+		assert.False(t, result.(*object.BoolObject).Value)
+	})
+
+	t.Run("error in evaluated right side", func(t *testing.T) {
+		assertError(t, evaluate("true && undefinedVar;"), "identifier undefinedVar not found")
+	})
+
+	t.Run("error in skipped right side is not reported", func(t *testing.T) {
+		result := evaluate("false && undefinedVar;")
+		require.IsType(t, &object.BoolObject{}, result)
+		assert.False(t, result.(*object.BoolObject).Value)
+	})
 }
 
 // =============================================================================
@@ -474,6 +687,45 @@ func TestTruthinessEvaluation(t *testing.T) {
 		result := evaluate("if (0) { 10; };")
 		require.IsType(t, object.NullObject{}, result) // 0 is falsy, returns null
 	})
+
+	// every object type has truthiness, checked through '!', 'if' and '||'
+	tableTests := []struct {
+		name   string
+		setup  string
+		truthy bool
+	}{
+		{"int", "let v = 1;", true},
+		{"zero", "let v = 0;", false},
+		{"float", "let v = 0.5;", true},
+		{"zero float", "let v = 0.0;", false},
+		{"true", "let v = true;", true},
+		{"false", "let v = false;", false},
+		{"string", "let v = 'a';", true},
+		{"empty string", "let v = '';", false},
+		{"array", "let v = [0];", true},
+		{"empty array", "let v = [];", false},
+		{"hash", "let v = #{'a': 0};", true},
+		{"empty hash", "let v = #{};", false},
+		{"null", "let v = if (false) { 1 };", false},
+		{"function", "let v = fn() { 0 };", true},
+	}
+
+	for _, tt := range tableTests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluate(tt.setup + " !v;")
+			require.IsType(t, &object.BoolObject{}, result)
+			assert.Equal(t, !tt.truthy, result.(*object.BoolObject).Value)
+
+			result = evaluate(tt.setup + " if (v) { 'yes' } else { 'no' };")
+			require.IsType(t, &object.StringObject{}, result)
+			expected := map[bool]string{true: "yes", false: "no"}[tt.truthy]
+			assert.Equal(t, expected, result.(*object.StringObject).Value)
+
+			result = evaluate(tt.setup + " !(v || false);")
+			require.IsType(t, &object.BoolObject{}, result)
+			assert.Equal(t, !tt.truthy, result.(*object.BoolObject).Value)
+		})
+	}
 }
 
 // =============================================================================
@@ -541,6 +793,111 @@ func TestInfixArithmeticErrors(t *testing.T) {
 	}
 }
 
+func TestModuloAndPowerEvaluation(t *testing.T) {
+	intTests := []struct {
+		input    string
+		expected int64
+	}{
+		{"7 % 3;", 1},
+		{"-7 % 3;", -1},
+		{"7 % -3;", 1},
+		{"1 + 10 % 4 * 2;", 5},
+		{"2 ** 10;", 1024},
+		{"2 ** 3 ** 2;", 512},
+		{"-2 ** 2;", -4},
+		{"(-2) ** 3;", -8},
+		{"5 ** 0;", 1},
+		{"2 ** 64;", 0},
+	}
+
+	for _, tt := range intTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.IntObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.IntObject).Value)
+		})
+	}
+
+	floatTests := []struct {
+		input    string
+		expected float64
+	}{
+		{"2 ** -1;", 0.5},
+		{"2.0 ** 0.5;", 1.4142135623730951},
+		{"7.5 % 2;", 1.5},
+		{"-7.5 % 2;", -1.5},
+	}
+
+	for _, tt := range floatTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.FloatObject{}, result)
+			assert.InDelta(t, tt.expected, result.(*object.FloatObject).Value, 1e-9)
+		})
+	}
+
+	t.Run("int modulo by zero is an error", func(t *testing.T) {
+		assertError(t, evaluate("1 % 0;"), "integer modulo by zero")
+	})
+
+	t.Run("string modulo is an error", func(t *testing.T) {
+		assertError(t, evaluate("'a' % 2;"), "cannot perform operation 'STRING % INT'")
+	})
+}
+
+func TestBitwiseEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"12 & 10;", 8},
+		{"12 | 10;", 14},
+		{"12 ^ 10;", 6},
+		{"~0;", -1},
+		{"~5;", -6},
+		{"1 << 4;", 16},
+		{"256 >> 4;", 16},
+		{"-16 >> 2;", -4},
+		{"1 << 64;", 0},
+		{"-1 >> 100;", -1},
+		{"1 | 2 ^ 3 & 6;", 1 | 2 ^ 3&6},
+		{"1 << 2 + 1;", 8},
+		{"0x0f & ~0x03;", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.IntObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.IntObject).Value)
+		})
+	}
+
+	t.Run("bitwise result compares without parens", func(t *testing.T) {
+		result := evaluate("6 & 1 == 0;")
+		require.IsType(t, &object.BoolObject{}, result)
+		assert.True(t, result.(*object.BoolObject).Value)
+	})
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.5 & 1;", "cannot perform operation 'FLOAT & INT'"},
+		{"true | false;", "cannot perform operation 'BOOL | BOOL'"},
+		{"'a' ^ 1;", "cannot perform operation 'STRING ^ INT'"},
+		{"1 << -1;", "negative shift count -1"},
+		{"~1.5;", "Unsupported operator: ~ for Floats"},
+		{"~true;", "Unsupported operator: ~ for Bools"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
 func TestIntegerComparisonEdgeCases(t *testing.T) {
 	t.Run("int equality uses direct comparison", func(t *testing.T) {
 		result := evaluate("5 == 5;")
@@ -602,6 +959,25 @@ func TestErrorPropagationInProgram(t *testing.T) {
 	})
 }
 
+func TestEmptyProgram(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"whitespace only", "   \n\t"},
+		{"comment only", "// only a comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, object.NullObject{}, result)
+			assert.Equal(t, "null", result.Inspect())
+		})
+	}
+}
+
 // =============================================================================
 // Return Statement Tests
 // =============================================================================
@@ -665,56 +1041,396 @@ func TestReturnStatementEvaluation(t *testing.T) {
 }
 
 // =============================================================================
-// Error in Subexpression Tests
+// Loop Tests
 // =============================================================================
 
-func TestErrorInSubexpressions(t *testing.T) {
-	t.Run("error in left infix operand propagates", func(t *testing.T) {
-		result := evaluate("(true + 5) + 10;")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
-	})
+func TestWhileEvaluation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+	}{
+		{
+			"sum",
+			"let i = 0; let sum = 0; while (i < 5) { i = i + 1; sum = sum + i; }; sum;",
+			15,
+		},
+		{
+			"false condition skips body",
+			"let x = 1; while (false) { x = 2; }; x;",
+			1,
+		},
+		{
+			"break from nested if",
+			"let i = 0; while (true) { if (i == 3) { break; } i = i + 1; }; i;",
+			3,
+		},
+		{
+			"continue from nested if",
+			`let i = 0; let odd = 0;
+			while (i < 10) { i = i + 1; if (i % 2 == 0) { continue; } odd = odd + 1; };
+			odd;`,
+			5,
+		},
+		{
+			"break leaves only inner loop",
+			`let i = 0; let count = 0;
+			while (i < 3) {
+				i = i + 1;
+				let j = 0;
+				while (true) { j = j + 1; if (j > 2) { break; } count = count + 1; }
+			};
+			count;`,
+			6,
+		},
+		{
+			"return from loop inside function",
+			"let find = fn() { let i = 0; while (true) { if (i * i > 50) { return i; } i = i + 1; } }; find();",
+			8,
+		},
+		{
+			"truthy condition",
+			"let arr = [1, 2, 3]; let n = 0; while (arr) { arr = rest(arr); n = n + 1; }; n;",
+			3,
+		},
+	}
 
-	t.Run("error in right infix operand propagates", func(t *testing.T) {
-		result := evaluate("10 + (true + 5);")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
-	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.IntObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.IntObject).Value)
+		})
+	}
 
-	t.Run("error in return value propagates", func(t *testing.T) {
-		result := evaluate("return true + 5;")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	t.Run("loop evaluates to null", func(t *testing.T) {
+		result := evaluate("let i = 0; while (i < 2) { i = i + 1; }")
+		require.IsType(t, object.NullObject{}, result)
 	})
 
-	t.Run("error in if condition propagates", func(t *testing.T) {
-		result := evaluate("if (true + 5) { 10; };")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	t.Run("each iteration has fresh block scope", func(t *testing.T) {
+		result := evaluate("let i = 0; while (i < 3) { let x = i; i = i + 1; }; i;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(3), result.(*object.IntObject).Value)
 	})
 
-	t.Run("error in else-if condition propagates", func(t *testing.T) {
-		result := evaluate("if (false) { 1; } else if (true + 5) { 2; };")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	t.Run("error in condition", func(t *testing.T) {
+		assertError(t, evaluate("while (missing) { 1; }"), "identifier missing not found")
 	})
 
-	t.Run("error in prefix operand propagates", func(t *testing.T) {
-		result := evaluate("-(true + 5);")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	t.Run("error in body stops loop", func(t *testing.T) {
+		assertError(t, evaluate("let i = 0; while (i < 3) { i = i + 1; i + true; }"), "cannot perform operation 'INT + BOOL'")
 	})
 
-	t.Run("error in bang prefix operand propagates", func(t *testing.T) {
-		result := evaluate("!(true + 5);")
-		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	t.Run("large loop does not grow the stack", func(t *testing.T) {
+		result := evaluate("let i = 0; while (i < 100000) { i = i + 1; }; i;")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(100000), result.(*object.IntObject).Value)
 	})
 }
 
-// =============================================================================
-// Complex Expression Tests
-// =============================================================================
+func TestLoopControlInExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let i = 0; while (i < 3) { i += 1; let a = [if (true) { break }]; }; i;", "1"},
+		{"let i = 0; while (i < 3) { i += 1; 1 + if (true) { break }; }; i;", "1"},
+		{"let i = 0; while (i < 3) { i += 1; \"${if (true) { break }}\"; }; i;", "1"},
+		{"let i = 0; while (i < 3) { i += 1; puts(if (true) { break }); }; i;", "1"},
+		{"let x = 0; for (i in 1..=3) { x = if (i == 2) { break } else { i }; }; x;", "1"},
+		{"let a = []; for (i in 1..=3) { a = a.push(#{'v': if (i == 2) { continue } else { i }}); }; a;", "[#{ v:1 }, #{ v:3 }]"},
+		{"let s = 0; for (i in 1..=4) { s += -if (i % 2 == 0) { continue } else { i }; }; s;", "-4"},
+		{"let f = fn() { let a = [1, if (true) { return 2 }]; 3 }; f();", "2"},
+		{"let f = fn() { 1 + if (true) { return 2 } }; f();", "2"},
+	}
 
-func TestComplexExpressions(t *testing.T) {
-	t.Run("arithmetic in condition", func(t *testing.T) {
-		result := evaluate("if ((5 + 5) == 10) { 100; };")
-		require.IsType(t, &object.IntObject{}, result)
-		assert.Equal(t, int64(100), result.(*object.IntObject).Value)
-	})
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+}
+
+func TestForEvaluation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			"array items",
+			"let out = ''; for (x in [1, 2, 3]) { out = out + x; }; out;",
+			"123",
+		},
+		{
+			"array index and item",
+			"let out = ''; for (i, x in ['a', 'b']) { out = out + i + x; }; out;",
+			"0a1b",
+		},
+		{
+			"string characters",
+			"let out = ''; for (c in 'héllo') { out = c + out; }; out;",
+			"olléh",
+		},
+		{
+			"string index and character",
+			"let out = ''; for (i, c in 'hé') { out = out + i + c; }; out;",
+			"0h1é",
+		},
+		{
+			"hash keys in order",
+			`let out = ''; for (k in #{'b': 1, 2: 2, 'a': 3, 1: 4, true: 5, false: 6}) { out = "${out}${k},"; }; out;`,
+			"false,true,1,2,a,b,",
+		},
+		{
+			"hash keys and values",
+			"let out = ''; for (k, v in #{'b': 1, 'a': 2}) { out = out + k + v; }; out;",
+			"a2b1",
+		},
+		{
+			"break and continue",
+			`let out = '';
+			for (x in [1, 2, 3, 4, 5]) { if (x == 2) { continue; } if (x == 4) { break; } out = out + x; };
+			out;`,
+			"13",
+		},
+		{
+			"empty collection",
+			"let out = 'none'; for (x in []) { out = 'some'; }; out;",
+			"none",
+		},
+		{
+			"changes to array in loop are not iterated",
+			"let arr = [1, 2]; let out = ''; for (x in arr) { arr = push(arr, x); out = out + x; }; out;",
+			"12",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.StringObject{}, result)
+			assert.Equal(t, tt.expected, result.(*object.StringObject).Value)
+		})
+	}
+
+	t.Run("closures see value of their own iteration", func(t *testing.T) {
+		result := evaluate(`
+			let fns = [];
+			for (i, x in [10, 20, 30]) { fns = push(fns, fn() { i * 100 + x }); };
+			fns[0]() + fns[1]() + fns[2]();`)
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(10+120+230), result.(*object.IntObject).Value)
+	})
+
+	t.Run("loop variables do not leak", func(t *testing.T) {
+		assertError(t, evaluate("for (x in [1]) {}; x;"), "identifier x not found")
+	})
+
+	t.Run("return from loop inside function", func(t *testing.T) {
+		result := evaluate("let firstBig = fn(arr) { for (x in arr) { if (x > 1) { return x; } } }; firstBig([1, 5, 7]);")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(5), result.(*object.IntObject).Value)
+	})
+
+	t.Run("loop evaluates to null", func(t *testing.T) {
+		require.IsType(t, object.NullObject{}, evaluate("for (x in [1]) { x }"))
+	})
+
+	t.Run("not iterable", func(t *testing.T) {
+		assertError(t, evaluate("for (x in 5) {}"), "cannot iterate over INT")
+	})
+
+	t.Run("error in body stops loop", func(t *testing.T) {
+		assertError(t, evaluate("for (x in [1, 2]) { x + true; }"), "cannot perform operation 'INT + BOOL'")
+	})
+}
+
+// =============================================================================
+// Range Tests
+// =============================================================================
+
+func TestRangeEvaluation(t *testing.T) {
+	inspectTests := []struct {
+		input    string
+		expected string
+	}{
+		{"0..5;", "0..5"},
+		{"1..=n;", "1..=3"},
+		{"10..0 step -2;", "10..0 step -2"},
+		{"array(0..5);", "[0, 1, 2, 3, 4]"},
+		{"array(0..=5);", "[0, 1, 2, 3, 4, 5]"},
+		{"array(0..10 step 3);", "[0, 3, 6, 9]"},
+		{"array(0..=9 step 3);", "[0, 3, 6, 9]"},
+		{"array(5..0 step -2);", "[5, 3, 1]"},
+		{"array(5..=1 step -2);", "[5, 3, 1]"},
+		{"array(5..0);", "[]"},
+		{"array(3..3);", "[]"},
+		{"array(3..=3);", "[3]"},
+	}
+
+	for _, tt := range inspectTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate("let n = 3; " + tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	intTests := []struct {
+		input    string
+		expected int64
+	}{
+		{"len(0..10);", 10},
+		{"len(0..=10);", 11},
+		{"len(0..10 step 3);", 4},
+		{"len(10..0 step -3);", 4},
+		{"len(10..0);", 0},
+		{"len(0..9223372036854775807);", 9223372036854775807},
+		// more elements than int64 can count
+		{"let m = -9223372036854775807 - 1; len(m..=9223372036854775807);", 9223372036854775807},
+		{"let m = -9223372036854775807 - 1; len(m..9223372036854775807);", 9223372036854775807},
+		{"let m = -9223372036854775807 - 1; len(9223372036854775807..=m step -1);", 9223372036854775807},
+		{"let m = -9223372036854775807 - 1; len(m..=9223372036854775807 step 2);", 9223372036854775807},
+		{"let m = -9223372036854775807 - 1; len(m..=9223372036854775807 step 3);", 6148914691236517206},
+		{"let m = -9223372036854775807 - 1; len(9223372036854775807..=m step m);", 2},
+		{"(0..100 step 5)[3];", 15},
+		{"(10..0 step -1)[0];", 10},
+		{"let sum = 0; for (i in 1..=100) { sum = sum + i; }; sum;", 5050},
+		{"let sum = 0; for (i, x in 10..13) { sum = sum + i * x; }; sum;", 0*10 + 1*11 + 2*12},
+		// only the elements used are computed
+		{"let r = 0..1000000000000; r[999999999999];", 999999999999},
+	}
+
+	for _, tt := range intTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.IntObject{}, result, result.Inspect())
+			assert.Equal(t, tt.expected, result.(*object.IntObject).Value)
+		})
+	}
+
+	membershipTests := []struct {
+		input    string
+		expected bool
+	}{
+		{"3 in 0..10;", true},
+		{"10 in 0..10;", false},
+		{"10 in 0..=10;", true},
+		{"-1 in 0..10;", false},
+		{"6 in 0..10 step 3;", true},
+		{"7 in 0..10 step 3;", false},
+		{"4 in 10..0 step -2;", true},
+		{"0 in 10..0 step -2;", false},
+		{"'a' in 0..10;", false},
+		{"1.0 in 0..3;", true},
+		{"-0.0 in 0..3;", true},
+		{"1.5 in 0..3;", false},
+		{"3.0 in 0..3;", false},
+		// far apart values do not overflow
+		{"let m = -9223372036854775807 - 1; 9223372036854775806 in (m..9223372036854775807 step 2);", true},
+		{"let m = -9223372036854775807 - 1; 9223372036854775807 in (m..=9223372036854775807 step 2);", false},
+		{"let m = -9223372036854775807 - 1; 9223372036854775807 in (m..=9223372036854775807);", true},
+		{"let m = -9223372036854775807 - 1; 9223372036854775807 in (m..9223372036854775807);", false},
+		{"let m = -9223372036854775807 - 1; m in (9223372036854775807..=m step -1);", true},
+		{"let m = -9223372036854775807 - 1; m in (9223372036854775807..=m step -2);", false},
+		{"let m = -9223372036854775807 - 1; (m + 1) in (9223372036854775807..=m step -2);", true},
+		{"let m = -9223372036854775807 - 1; 0 in (9223372036854775807..m step -3);", false},
+		{"let m = -9223372036854775807 - 1; 1 in (9223372036854775807..m step -3);", true},
+		{"2 in [1, 2, 3];", true},
+		{"2.0 in [1, 2, 3];", true},
+		{"'x' in [1, 2, 3];", false},
+		{"'ell' in 'hello';", true},
+		{"'a' in #{'a': 1};", true},
+		{"'b' in #{'a': 1};", false},
+		{"!(5 in 0..3);", true},
+		{"if (0..0) { true } else { false };", false},
+	}
+
+	for _, tt := range membershipTests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.BoolObject{}, result, result.Inspect())
+			assert.Equal(t, tt.expected, result.(*object.BoolObject).Value)
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"0..1.5;", "range end must be INT, but was FLOAT"},
+		{"'a'..3;", "range start must be INT, but was STRING"},
+		{"0..10 step 0;", "range step must not be 0"},
+		{"(0..3)[3];", "index 3 out of bounds for range of length 3"},
+		{"(0..3)['a'];", "index must be INT, but was STRING"},
+		{"1 in 'abc';", "cannot perform operation 'INT in STRING'"},
+		{"[1] in #{};", "cannot perform operation 'ARRAY in HASH'"},
+		{"1 in 2;", "cannot perform operation 'INT in INT'"},
+		{"array(#{});", "'array' accepts only ARRAY, RANGE or STRING argument, but was HASH"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+// =============================================================================
+// Error in Subexpression Tests
+// =============================================================================
+
+func TestErrorInSubexpressions(t *testing.T) {
+	t.Run("error in left infix operand propagates", func(t *testing.T) {
+		result := evaluate("(true + 5) + 10;")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in right infix operand propagates", func(t *testing.T) {
+		result := evaluate("10 + (true + 5);")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in return value propagates", func(t *testing.T) {
+		result := evaluate("return true + 5;")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in if condition propagates", func(t *testing.T) {
+		result := evaluate("if (true + 5) { 10; };")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in else-if condition propagates", func(t *testing.T) {
+		result := evaluate("if (false) { 1; } else if (true + 5) { 2; };")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in prefix operand propagates", func(t *testing.T) {
+		result := evaluate("-(true + 5);")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("error in bang prefix operand propagates", func(t *testing.T) {
+		result := evaluate("!(true + 5);")
+		assertError(t, result, "cannot perform operation 'BOOL + INT'")
+	})
+}
+
+// =============================================================================
+// Complex Expression Tests
+// =============================================================================
+
+func TestComplexExpressions(t *testing.T) {
+	t.Run("arithmetic in condition", func(t *testing.T) {
+		result := evaluate("if ((5 + 5) == 10) { 100; };")
+		require.IsType(t, &object.IntObject{}, result)
+		assert.Equal(t, int64(100), result.(*object.IntObject).Value)
+	})
 
 	t.Run("logical in condition", func(t *testing.T) {
 		result := evaluate("if (true && true) { 42; };")
@@ -805,6 +1521,13 @@ func TestIdentifierErrors(t *testing.T) {
 		result := evaluate("5 + unknown;")
 		assertError(t, result, "identifier unknown not found")
 	})
+
+	t.Run("error carries position of failed expression", func(t *testing.T) {
+		result := evaluate("let x = 1;\nlet y = x + true;")
+		assertError(t, result, "cannot perform operation 'INT + BOOL'")
+		assert.Equal(t, "2:9", result.(*object.ErrorObject).Pos.String())
+		assert.Equal(t, "2:9: cannot perform operation 'INT + BOOL'", result.Inspect())
+	})
 }
 
 // =============================================================================
@@ -855,18 +1578,533 @@ func TestAssignmentOperatorEvaluation(t *testing.T) {
 	})
 }
 
+func TestCompoundAssignmentEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 5; x += 2; x;", "7"},
+		{"let x = 5; x -= 2; x;", "3"},
+		{"let x = 5; x *= 2; x;", "10"},
+		{"let x = 7; x /= 2; x;", "3"},
+		{"let x = 7; x %= 4; x;", "3"},
+		{"let x = 1; x += 0.5; x;", "1.5"},
+		{"let s = 'ab'; s += 'c'; s;", "abc"},
+		{"let x = 5; x += 2;", "7"},
+		{"let x = 1; let y = 2; x += y += 3; x;", "6"},
+		{"let x = 0; let f = fn() { x += 1; }; f(); f(); x;", "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	t.Run("undefined variable", func(t *testing.T) {
+		assertError(t, evaluate("x += 1;"), "x is not defined")
+	})
+
+	t.Run("operator error", func(t *testing.T) {
+		assertError(t, evaluate("let x = true; x += 1;"), "cannot perform operation 'BOOL + INT'")
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		assertError(t, evaluate("let x = 1; x /= 0;"), "integer division by zero")
+	})
+}
+
+func TestIndexAssignmentEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1, 2, 3]; a[0] = 10; a;", "[10, 2, 3]"},
+		{"let a = [1, 2, 3]; a[2] += 5; a;", "[1, 2, 8]"},
+		{"let a = [1, 2, 3]; a[1] = 7;", "7"},
+		{"let h = #{'a': 1}; h['a'] = 2; h['b'] = 3; h;", "#{ a:2, b:3 }"},
+		{"let h = #{'n': 1}; h['n'] *= 10; h['n'];", "10"},
+		{"let h = #{}; h[1] = 'one'; h[true] = 'yes'; h;", "#{ true:yes, 1:one }"},
+		{"let m = [[1, 2], [3, 4]]; m[1][0] = 9; m;", "[[1, 2], [9, 4]]"},
+		{"let h = #{'list': [1]}; h['list'][0] -= 1; h;", "#{ list:[0] }"},
+		{"let a = [0, 0]; let i = 0; a[i] = i = 1; a;", "[1, 0]"},
+		// arrays and hashes are shared, not copied
+		{"let a = [1, 2]; let b = a; b[0] = 5; a;", "[5, 2]"},
+		{"let h = #{}; let set = fn(x) { x['k'] = 1 }; set(h); h;", "#{ k:1 }"},
+		// push makes a new array, so the original one stays the same
+		{"let a = [1]; let b = push(a, 2); b[0] = 9; a;", "[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [1]; a[1] = 2;", "index 1 out of bounds for array of length 1"},
+		{"let a = [1]; a['x'] = 2;", "index must be INT, but was STRING"},
+		{"let h = #{}; h[[1]] = 2;", "index must be [STRING, INT, BOOL], but was ARRAY"},
+		{"let h = #{}; h['x'] += 1;", "cannot perform operation 'NULL + INT'"},
+		{"let s = 'abc'; s[0] = 'x';", "cannot assign to element of STRING"},
+		{"missing[0] = 1;", "identifier missing not found"},
+		{"let a = [1]; a[0] = missing;", "identifier missing not found"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
 func TestAssignmentOperatorErrors(t *testing.T) {
 	t.Run("assignment to undefined variable", func(t *testing.T) {
 		result := evaluate("x = 5;")
 		assertError(t, result, "x is not defined")
 	})
+}
 
-	t.Run("assignment to literal is invalid", func(t *testing.T) {
-		result := evaluate("5 = 10;")
-		assertError(t, result, "cannot perform operation")
+func TestNullSafeEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"null;", "null"},
+		{"null ?? 5;", "5"},
+		{"0 ?? 5;", "0"},
+		{"false ?? 5;", "false"},
+		{"len('' ?? 'abc');", "0"},
+		{"null ?? null ?? 'last';", "last"},
+		{"let cfg = #{'db': #{'port': 5432}}; cfg?['db']?['port'] ?? 80;", "5432"},
+		{"let cfg = #{'db': #{'port': 5432}}; cfg?['db']?['host'] ?? 'localhost';", "localhost"},
+		{"let cfg = null; cfg?['db']?['host'] ?? 'localhost';", "localhost"},
+		{"let cfg = #{'db': #{'port': 5432}}; cfg?.db?.port;", "5432"},
+		{"let cfg = #{}; cfg?.db?.port ?? 80;", "80"},
+		{"let cfg = null; cfg?.db;", "null"},
+		// right side is evaluated only when needed
+		{"let n = 0; let f = fn() { n = n + 1 }; 1 ?? f(); n;", "0"},
+		{"let a = [null]; a[0]?[1]?[missing];", "null"},
+		{"null == null;", "true"},
+		{"null != 1;", "true"},
+		{"'a' == null;", "false"},
+		{"!null;", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = 5; a?.b;", "cannot access field b of a, it is INT"},
+		{"let a = null; a['x'];", "can index only [STRING, ARRAY, HASH, RANGE], but was NULL"},
+		// only the marked step is null-safe
+		{"let a = #{'b': null}; a?['b']['c'];", "can index only [STRING, ARRAY, HASH, RANGE], but was NULL"},
+		{"missing ?? 1;", "identifier missing not found"},
+		{"null < 1;", "cannot perform operation 'NULL < INT'"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestMemberExpressionEvaluation(t *testing.T) {
+	person := "let person = #{'name': 'Ann', 'address': #{'city': 'Oslo'}, 'age': 30};"
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"person.name;", "Ann"},
+		{"person.address.city;", "Oslo"},
+		{"person.address.city == person['address']['city'];", "true"},
+		{"person.address.city = 'Bergen'; person.address;", "#{ city:Bergen }"},
+		{"person.age += 1; person.age;", "31"},
+		{"person.email = 'ann@example.com'; person.email;", "ann@example.com"},
+		{"person.address.zip = 1234; person['address']['zip'];", "1234"},
+		{"let a = person.address; a.city = 'Bergen'; person.address.city;", "Bergen"},
+		{"person?.address?.zip ?? 0;", "0"},
+		// '.' is sugar for index access, so missing field is null like
+		// missing key is
+		{"person.address.zip;", "null"},
+		{"person.address.zip == person['address']['zip'];", "true"},
+		{"person.address.zip ?? 'none';", "none"},
+		{"#{'a': [#{'b': 2}]}.a[0].b;", "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(person + tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"person.phone.mobile;", "cannot access field mobile of person.phone, it is NULL"},
+		{"person.name.first;", "cannot access field first of person.name, it is STRING"},
+		{"person.zip += 1;", "cannot perform operation 'NULL + INT'"},
+		{"person.name.first = 'A';", "cannot assign to field first of person.name, it is STRING"},
+		{"person.address.zip.code = 1;", "cannot assign to field code of person.address.zip, it is NULL"},
+		{"freeze(person); person.address.city = 'Bergen';", "cannot assign to field city of frozen HASH"},
+		{"person.age = missing;", "identifier missing not found"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(person+tt.input), tt.expected)
+		})
+	}
+
+	t.Run("error points at the field of null", func(t *testing.T) {
+		result := evaluate("let cfg = #{};\nlet host = cfg.db.host;")
+		assert.Equal(t, "2:19: cannot access field host of cfg.db, it is NULL", result.Inspect())
+	})
+}
+
+func TestMethodCallEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"'abc'.len();", "3"},
+		{"[1, 2, 3].push(4);", "[1, 2, 3, 4]"},
+		{"[1, 2, 3].first() + [1, 2, 3].last();", "4"},
+		{"'12'.int() + 1;", "13"},
+		{"3.float() / 2;", "1.5"},
+		{"(0..3).array();", "[0, 1, 2]"},
+		{"let xs = [1, 2, 3, 4]; xs.map(fn(x) { x * 10 }).filter(fn(x) { x > 15 });", "[20, 30, 40]"},
+		{"let xs = [1, 2]; xs.push(3).rest().len();", "2"},
+		// function stored in a hash field is called without receiver
+		{"let counter = #{'n': 1, 'next': fn(x) { x + 1 }}; counter.next(counter.n);", "2"},
+		{"let h = #{'size': len}; h.size('abc');", "3"},
+		{"let s = null; s?.len() ?? 0;", "0"},
+		{"freeze(#{}).freeze();", "#{  }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1].nope();", "ARRAY has no method nope"},
+		{"'abc'.push(1);", "STRING has no method push"},
+		{"#{}.len();", "HASH has no method len"},
+		{"let h = #{'n': 1}; h.n();", "'h.n' is not a function, got INT"},
+		{"let s = null; s.len();", "NULL has no method len"},
+		{"[1].push();", "'push' requires exactly two arguments, but had 1"},
+		{"missing.len();", "identifier missing not found"},
+		{"[1].push(missing);", "identifier missing not found"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestMapAndFilterBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"map([1, 2, 3], fn(x) { x * 2 });", "[2, 4, 6]"},
+		{"map(0..3, fn(x) { x * x });", "[0, 1, 4]"},
+		{"map([], fn(x) { x });", "[]"},
+		{"map(['a', 'bc'], len);", "[1, 2]"},
+		{"filter([1, 2, 3, 4], fn(x) { x % 2 == 0 });", "[2, 4]"},
+		{"filter(['', 'a', null, 0, 1], fn(x) { x });", "[a, 1]"},
+		{"filter(1..=10, fn(x) { x % 5 == 0 });", "[5, 10]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"map([1]);", "'map' requires exactly two arguments, but had 1"},
+		{"map('abc', len);", "'map' first argument must be ARRAY or RANGE, but was STRING"},
+		{"filter([1], 1);", "'filter' second argument must be FN or BUILTIN_FN, but was INT"},
+		{"map([1, 'a'], fn(x) { x - 1 });", "cannot perform operation 'STRING - INT'"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestBuiltinsAsValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let size = len; size([1, 2]);", "2"},
+		{"len;", "fn len(...) { ...builtin... }"},
+		// variables shadow builtins
+		{"let first = fn(x) { 'mine' }; first([1]);", "mine"},
+		{"let f = fn() { let len = 5; len }; f();", "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	assertError(t, evaluate("let len = 5; len('a');"), "'len' is not a function, got INT")
+}
+
+func TestPipeEvaluation(t *testing.T) {
+	functions := `
+		let double = fn(x) { x * 2 };
+		let isEven = fn(x) { x % 2 == 0 };
+		let sum = fn(xs) { let total = 0; for (x in xs) { total += x; }; total };
+		let add = fn(a, b) { a + b };
+	`
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 |> double;", "10"},
+		{"5 |> add(1);", "6"},
+		{"[1, 2, 3] |> map(double) |> sum;", "12"},
+		{"1..=6 |> filter(isEven) |> map(double);", "[4, 8, 12]"},
+		{"sum(filter(map([1, 2, 3], double), isEven)) == ([1, 2, 3] |> map(double) |> filter(isEven) |> sum);", "true"},
+		{"'abc' |> len;", "3"},
+		{"2 + 3 |> fn(x) { x * 10 };", "50"},
+		{"5 |> fn(a, b) { a - b }(1);", "4"},
+		{"let ops = #{'add': add}; 5 |> ops.add(2);", "7"},
+		{"[3] |> [1, 2].push();", "[1, 2, [3]]"},
+		// left side is evaluated before the arguments
+		{"let log = []; let note = fn(x) { log = push(log, x); x }; note(1) |> add(note(2)); log;", "[1, 2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(functions + tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 |> 6;", "'6' is not a function, got INT"},
+		{"5 |> missing;", "identifier missing not found"},
+		{"missing |> double;", "identifier missing not found"},
+		{"[1] |> push();", "'push' requires exactly two arguments, but had 1"},
+		{"true |> double |> add(1);", "cannot perform operation 'BOOL * INT'"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(functions+tt.input), tt.expected)
+		})
+	}
+
+	t.Run("error is positioned at the call", func(t *testing.T) {
+		result := evaluate("[1]\n  |> push();")
+		assert.Equal(t, "2:6: 'push' requires exactly two arguments, but had 1", result.Inspect())
 	})
 }
 
+func TestFnParametersEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let f = fn(a, b = 10) { a + b }; [f(1), f(1, 2)];", "[11, 3]"},
+		{"let f = fn(a, b = a * 2) { a + b }; f(3);", "9"},
+		{"let n = 0; let f = fn(a = n) { a }; n = 5; f();", "5"},
+		{"let f = fn(first, ...rest) { [first, rest] }; [f(1), f(1, 2, 3)];", "[[1, []], [1, [2, 3]]]"},
+		{"fn(x = 1, ...xs) { [x, xs] }();", "[1, []]"},
+		{"let f = fn(a, b = 10, ...rest) { a }; f;", "fn (a, b = 10, ...rest) {a;}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let add = fn(a, b) { a + b }; add(1);", "'add' requires exactly 2 arguments, but had 1"},
+		{"let add = fn(a, b) { a + b }; add(1, 2, 3);", "'add' requires exactly 2 arguments, but had 3"},
+		{"let f = fn(a, b = 10) { a + b }; f();", "'f' requires from 1 to 2 arguments, but had 0"},
+		{"let f = fn(first, ...rest) { rest }; f();", "'f' requires at least 1 argument, but had 0"},
+		{"let f = fn(a, b = missing) { a }; f(1);", "identifier missing not found"},
+		{"let ops = #{'inc': fn(x) { x + 1 }}; ops.inc();", "'ops.inc' requires exactly 1 argument, but had 0"},
+		{"[1, 2].map(fn(a, b) { a });", "anonymous function requires exactly 2 arguments, but had 1"},
+		{"let pair = fn(a, b) { a }; [1, 2].map(pair);", "'pair' requires exactly 2 arguments, but had 1"},
+		{"1 |> fn() { 1 };", "anonymous function requires exactly 0 arguments, but had 1"},
+		{"fn(x) { x }();", "anonymous function requires exactly 1 argument, but had 0"},
+		{"(x => x)();", "anonymous function requires exactly 1 argument, but had 0"},
+		{"let add = a => b => a + b; add(1)();", "anonymous function requires exactly 1 argument, but had 0"},
+		{"let fns = [fn(x) { x }]; fns[0]();", "anonymous function requires exactly 1 argument, but had 0"},
+		{"let double = x => x * 2; let fns = [double]; fns[0]();", "'double' requires exactly 1 argument, but had 0"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+
+	t.Run("error is positioned at the call", func(t *testing.T) {
+		result := evaluate("let add = fn(a, b) { a + b };\nadd(1);")
+		assert.Equal(t, "2:1: 'add' requires exactly 2 arguments, but had 1", result.Inspect())
+	})
+}
+
+func TestArrowFunctionEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2, 3].map(x => x * 2);", "[2, 4, 6]"},
+		{"[1, 2, 3, 4] |> filter(x => x % 2 == 0);", "[2, 4]"},
+		{"((a, b) => a + b)(1, 2);", "3"},
+		{"(() => 1)();", "1"},
+		{"let f = x => { if (x > 0) { return 'positive'; }; 'other' }; [f(1), f(0)];", "[positive, other]"},
+		{"let add = a => b => a + b; add(1)(2);", "3"},
+		{"let n = 10; let addN = x => x + n; n = 20; addN(1);", "21"},
+		{"let ops = #{'inc': x => x + 1}; ops.inc(1);", "2"},
+		{"let add = (a, b = 10) => a + b; [add(1), add(1, 2)];", "[11, 3]"},
+		{"let tail = (first, ...rest) => rest; tail(1, 2, 3);", "[2, 3]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+}
+
+func TestConstEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 5; x * 2;", "10"},
+		{"const x = [1]; x[0] = 2; x;", "[2]"},
+		// constant can be shadowed in inner scope, shadowing one is a variable
+		{"const x = 1; let f = fn() { let x = 2; x = 3; x }; f();", "3"},
+		{"const x = 1; for (x in 0..2) { x += 10; }; x;", "1"},
+		{"let x = 1; { const x = 2; }; x = 5;", "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 5; x = 6;", "cannot assign to constant x declared at 1:7"},
+		{"const x = 5;\nx += 1;", "cannot assign to constant x declared at 1:7"},
+		{"const x = 5; let f = fn() { x = 1 }; f();", "cannot assign to constant x declared at 1:7"},
+		{"const x = 5; let x = 6;", "x is already declared as constant at 1:7"},
+		{"const x = 5; const x = 6;", "x is already declared as constant at 1:7"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestFreezeBuiltin(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"freeze([1, 2]);", "[1, 2]"},
+		{"freeze(5);", "5"},
+		{"let a = freeze([1]); let b = push(a, 2); b[0] = 9; b;", "[9, 2]"},
+		{"let a = [1]; a[0] = a; freeze(a); 1;", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = freeze([1]); a[0] = 2;", "cannot assign to element of frozen ARRAY"},
+		{"let h = freeze(#{'a': 1}); h['b'] = 2;", "cannot assign to element of frozen HASH"},
+		{"let c = #{'list': [1]}; freeze(c); c['list'][0] += 1;", "cannot assign to element of frozen ARRAY"},
+		// freezing changes the value itself, every reference sees it
+		{"let a = [1]; let b = a; freeze(b); a[0] = 2;", "cannot assign to element of frozen ARRAY"},
+		{"let h = #{}; let f = fn(x) { x['k'] = 1 }; f(freeze(h));", "cannot assign to element of frozen HASH"},
+		{"freeze();", "'freeze' requires exactly one argument, but had 0"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
 // =============================================================================
 // Comparison Operator Tests (< and >)
 // =============================================================================
@@ -886,6 +2124,15 @@ func TestComparisonOperatorEvaluation(t *testing.T) {
 		{"5 > 10;", false},
 		{"5 > 5;", false},
 
+		// Less or equal, greater or equal
+		{"5 <= 5;", true},
+		{"6 <= 5;", false},
+		{"5 >= 5;", true},
+		{"4 >= 5;", false},
+		{"1.5 <= 2;", true},
+		{"2 >= 2.0;", true},
+		{"1 + 1 <= 2 == true;", true},
+
 		// With expressions
 		{"(1 + 2) < 5;", true},
 		{"(5 - 1) > 3;", true},
@@ -922,6 +2169,11 @@ func TestComparisonOperatorErrors(t *testing.T) {
 		result := evaluate("true > false;")
 		assertError(t, result, "cannot perform operation")
 	})
+
+	t.Run("string <= string is invalid", func(t *testing.T) {
+		result := evaluate("'a' <= 'b';")
+		assertError(t, result, "cannot perform operation 'STRING <= STRING'")
+	})
 }
 
 // =============================================================================
@@ -1052,6 +2304,8 @@ func TestStringIndexExpression(t *testing.T) {
 		{"'hello'[2];", "l"},
 		{"let s = 'abc'; s[1];", "b"},
 		{"let i = 2; 'xyz'[i];", "z"},
+		{"'日本語'[1];", "本"},
+		{"let 名前 = '😀🎉'; 名前[1];", "🎉"},
 	}
 
 	for _, tt := range tests {
@@ -1140,9 +2394,9 @@ func TestIndexExpressionOutOfBounds(t *testing.T) {
 		assertError(t, result, "out of bounds")
 	})
 
-	t.Run("array negative index", func(t *testing.T) {
-		result := evaluate("[1, 2, 3][-1];")
-		assertError(t, result, "out of bounds")
+	t.Run("array negative index before the first element", func(t *testing.T) {
+		result := evaluate("[1, 2, 3][-4];")
+		assertError(t, result, "index -4 out of bounds for array of length 3")
 	})
 
 	t.Run("string index too large", func(t *testing.T) {
@@ -1150,10 +2404,146 @@ func TestIndexExpressionOutOfBounds(t *testing.T) {
 		assertError(t, result, "out of bounds")
 	})
 
-	t.Run("string negative index", func(t *testing.T) {
-		result := evaluate("'hello'[-1];")
-		assertError(t, result, "out of bounds")
+	t.Run("string negative index before the first character", func(t *testing.T) {
+		result := evaluate("'hello'[-6];")
+		assertError(t, result, "index -6 out of bounds for string of length 5")
+	})
+}
+
+func TestNegativeIndexExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2, 3][-1];", "3"},
+		{"[1, 2, 3][-3];", "1"},
+		{"'héllo'[-4];", "é"},
+		{"(0..10 step 2)[-1];", "8"},
+		{"#{-1: 'minus one'}[-1];", "minus one"},
+		{"let a = [1, 2, 3]; a[-1] = 30; a[-2] += 10; a;", "[1, 12, 30]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	assertError(t, evaluate("let a = [1]; a[-2] = 0;"), "index -2 out of bounds for array of length 1")
+}
+
+func TestSliceExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[0, 1, 2, 3, 4][1:3];", "[1, 2]"},
+		{"[0, 1, 2, 3, 4][:2];", "[0, 1]"},
+		{"[0, 1, 2, 3, 4][3:];", "[3, 4]"},
+		{"[0, 1, 2, 3, 4][:];", "[0, 1, 2, 3, 4]"},
+		{"[0, 1, 2, 3, 4][-2:];", "[3, 4]"},
+		{"[0, 1, 2, 3, 4][1:-1];", "[1, 2, 3]"},
+		{"[0, 1, 2, 3, 4][::2];", "[0, 2, 4]"},
+		{"[0, 1, 2, 3, 4][1::2];", "[1, 3]"},
+		{"[0, 1, 2, 3, 4][::-1];", "[4, 3, 2, 1, 0]"},
+		{"[0, 1, 2, 3, 4][3:0:-1];", "[3, 2, 1]"},
+		{"[0, 1, 2, 3, 4][-1:-4:-2];", "[4, 2]"},
+		{"[0, 1, 2, 3, 4][::-9223372036854775807 - 1];", "[4]"},
+		// bounds out of range are clamped
+		{"[0, 1, 2][1:100];", "[1, 2]"},
+		{"[0, 1, 2][-100:1];", "[0]"},
+		{"[0, 1, 2][5:];", "[]"},
+		{"[0, 1, 2][2:1];", "[]"},
+		{"[0, 1, 2][100::-1];", "[2, 1, 0]"},
+		{"[][:3];", "[]"},
+		// null is the same as a missing bound
+		{"[0, 1, 2][null:null:null];", "[0, 1, 2]"},
+		// strings are sliced by characters
+		{"'hello'[1:4];", "ell"},
+		{"'héllo wörld'[-5:];", "wörld"},
+		{"'héllo'[::-1];", "olléh"},
+		{"let s = 'héllo'; s[len(s) - 1:];", "o"},
+		{"let s = null; s?[1:];", "null"},
+		// slice is a new array
+		{"let a = [1, 2]; let b = a[:]; b[0] = 9; a;", "[1, 2]"},
+		{"let a = freeze([1, 2]); let b = a[:]; b[0] = 9; b;", "[9, 2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+			assert.Equal(t, tt.expected, result.Inspect())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2][::0];", "slice step must not be 0"},
+		{"[1, 2][1.5:];", "slice start must be INT, but was FLOAT"},
+		{"[1, 2][:'a'];", "slice end must be INT, but was STRING"},
+		{"[1, 2][::true];", "slice step must be INT, but was BOOL"},
+		{"#{}[1:2];", "can slice only [STRING, ARRAY], but was HASH"},
+		{"(0..5)[1:2];", "can slice only [STRING, ARRAY], but was RANGE"},
+		{"[1][missing:];", "identifier missing not found"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
+}
+
+// =============================================================================
+// Builtin Function Tests: len
+// =============================================================================
+
+func TestLenBuiltin(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"len('');", 0},
+		{"len('hello');", 5},
+		// strings are counted in characters, not bytes, same as indexing
+		// and slicing use them: 'héllo' has 6 bytes, '😀' has 4
+		{"len('héllo');", 5},
+		{"len('😀');", 1},
+		{"len([1, 2, 3]);", 3},
+		{"len(0..3);", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := evaluate(tt.input)
+			require.IsType(t, &object.IntObject{}, result, result.Inspect())
+			assert.Equal(t, tt.expected, result.(*object.IntObject).Value)
+		})
+	}
+
+	t.Run("last character by len", func(t *testing.T) {
+		result := evaluate("let s = 'héllo'; s[len(s) - 1];")
+		require.NotEqual(t, object.ERROR, result.Type(), result.Inspect())
+		assert.Equal(t, "o", result.Inspect())
 	})
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"len(1);", "'len' accepts only STRING, ARRAY or RANGE arguments, but was INT"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertError(t, evaluate(tt.input), tt.expected)
+		})
+	}
 }
 
 // =============================================================================
@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalForStatement runs body for every element of the collection, loop
+// itself evaluates to null. Loop variables are bound in a new scope on
+// every iteration, so closures created in the body keep their own values.
+// With one variable it gets the array or range item, the string character
+// or the hash key, with two variables they get index and item, index and
+// character or key and value
+func evalForStatement(scope *object.Scope, node *ast.ForStatement) object.Object {
+	iterable := Eval(scope, node.Iterable)
+	if isInterrupted(iterable) {
+		return iterable
+	}
+
+	var result object.Object = object.NULL_OBJECT
+	err := iterate(iterable, func(key, value object.Object) bool {
+		iteration := scope.Spawn()
+		switch {
+		case node.Key != nil:
+			iteration.Add(node.Key.Value, key)
+			iteration.Add(node.Value.Value, value)
+		case isType(object.HASH, iterable):
+			iteration.Add(node.Value.Value, key)
+		default:
+			iteration.Add(node.Value.Value, value)
+		}
+
+		res := Eval(iteration, node.Body)
+		switch {
+		case isOneOfTypes(res, object.ERROR, object.RETURN):
+			result = res
+			return false
+		case isType(object.BREAK, res):
+			return false
+		}
+		// CONTINUE only cuts the body short
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return result
+}
+
+// iterate calls visit with index and item of an array or range, index
+// and character of a string or key and value of a hash, in order of hash
+// keys. Collection is iterated as it was when iteration started.
+// Iteration stops early when visit returns false
+func iterate(iterable object.Object, visit func(key, value object.Object) bool) *object.ErrorObject {
+	switch iterable := iterable.(type) {
+	case *object.ArrayObject:
+		items := append([]object.Object{}, iterable.Items...)
+		for i, item := range items {
+			if !visit(&object.IntObject{Value: int64(i)}, item) {
+				return nil
+			}
+		}
+	case *object.RangeObject:
+		for i := int64(0); i < iterable.Len(); i++ {
+			if !visit(&object.IntObject{Value: i}, &object.IntObject{Value: iterable.At(i)}) {
+				return nil
+			}
+		}
+	case *object.StringObject:
+		for i, char := range []rune(iterable.Value) {
+			if !visit(&object.IntObject{Value: int64(i)}, &object.StringObject{Value: string(char)}) {
+				return nil
+			}
+		}
+	case *object.HashObject:
+		keys := iterable.Keys()
+		values := make([]object.Object, len(keys))
+		for i, key := range keys {
+			values[i] = iterable.Map[key]
+		}
+		for i, key := range keys {
+			if !visit(hashKeyToObject(key), values[i]) {
+				return nil
+			}
+		}
+	default:
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("cannot iterate over %s", iterable.Type()),
+			},
+		}
+	}
+	return nil
+}
@@ -7,8 +7,7 @@ import (
 
 func evalIfExpression(scope *object.Scope, ifExpression *ast.IfExpression) object.Object {
 	ifConditionResult := Eval(scope, ifExpression.Condition)
-	ifConditionResult = resolveIdentIfNeeded(scope, ifConditionResult)
-	if isType(object.ERROR, ifConditionResult) {
+	if isInterrupted(ifConditionResult) {
 		return ifConditionResult
 	}
 
@@ -20,8 +19,7 @@ func evalIfExpression(scope *object.Scope, ifExpression *ast.IfExpression) objec
 
 	for i := range ifExpression.ElseIfBlocks {
 		elseIfConditionResult := Eval(scope, ifExpression.ElseIfBlocks[i].Condition)
-		elseIfConditionResult = resolveIdentIfNeeded(scope, elseIfConditionResult)
-		if isType(object.ERROR, elseIfConditionResult) {
+		if isInterrupted(elseIfConditionResult) {
 			return elseIfConditionResult
 		}
 		if convertToBoolish(elseIfConditionResult) {
@@ -8,10 +8,24 @@ import (
 	"monkey/object"
 )
 
+// elementIndex turns index of array, string or range into position of
+// element, negative index counts from the end: -1 is the last element
+func elementIndex(index, length int64) (int64, bool) {
+	if index < 0 {
+		index += length
+	}
+	return index, index >= 0 && index < length
+}
+
 func evalIndexExpression(scope *object.Scope, node *ast.IndexExpression) object.Object {
 	source := Eval(scope, node.Identifier)
-	source = resolveIdentIfNeeded(scope, source)
-	if !isOneOfTypes(source, object.ARRAY, object.STRING, object.HASH) {
+	if isInterrupted(source) {
+		return source
+	}
+	if node.Optional && isType(object.NULL, source) {
+		return object.NULL_OBJECT
+	}
+	if !isOneOfTypes(source, object.ARRAY, object.STRING, object.HASH, object.RANGE) {
 		return &object.ErrorObject{
 			Message: &object.StringObject{
 				Value: fmt.Sprintf("can index only [%s], but was %s",
@@ -20,6 +34,7 @@ func evalIndexExpression(scope *object.Scope, node *ast.IndexExpression) object.
 							string(object.STRING),
 							string(object.ARRAY),
 							string(object.HASH),
+							string(object.RANGE),
 						},
 						", ",
 					),
@@ -29,7 +44,9 @@ func evalIndexExpression(scope *object.Scope, node *ast.IndexExpression) object.
 	}
 
 	index := Eval(scope, node.IndexExpression)
-	index = resolveIdentIfNeeded(scope, index)
+	if isInterrupted(index) {
+		return index
+	}
 
 	switch source := source.(type) {
 	case *object.ArrayObject, *object.StringObject:
@@ -43,26 +60,46 @@ func evalIndexExpression(scope *object.Scope, node *ast.IndexExpression) object.
 		intObject := index.(*object.IntObject)
 
 		if sourceArr, isArray := source.(*object.ArrayObject); isArray {
-			if intObject.Value < 0 || intObject.Value >= int64(len(sourceArr.Items)) {
+			position, inBounds := elementIndex(intObject.Value, int64(len(sourceArr.Items)))
+			if !inBounds {
 				return &object.ErrorObject{
 					Message: &object.StringObject{
 						Value: fmt.Sprintf("index %d out of bounds for array of length %d", intObject.Value, len(sourceArr.Items)),
 					},
 				}
 			}
-			return sourceArr.Items[intObject.Value]
+			return sourceArr.Items[position]
 		} else if sourceStr, isString := source.(*object.StringObject); isString {
 			runes := []rune(sourceStr.Value)
-			if intObject.Value < 0 || intObject.Value >= int64(len(runes)) {
+			position, inBounds := elementIndex(intObject.Value, int64(len(runes)))
+			if !inBounds {
 				return &object.ErrorObject{
 					Message: &object.StringObject{
 						Value: fmt.Sprintf("index %d out of bounds for string of length %d", intObject.Value, len(runes)),
 					},
 				}
 			}
-			return &object.StringObject{Value: string(runes[intObject.Value])}
+			return &object.StringObject{Value: string(runes[position])}
 		}
 		panic("Indexed type is not array or string")
+	case *object.RangeObject:
+		intObject, isInt := index.(*object.IntObject)
+		if !isInt {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("index must be INT, but was %s", index.Type()),
+				},
+			}
+		}
+		position, inBounds := elementIndex(intObject.Value, source.Len())
+		if !inBounds {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("index %d out of bounds for range of length %d", intObject.Value, source.Len()),
+				},
+			}
+		}
+		return &object.IntObject{Value: source.At(position)}
 	case *object.HashObject:
 		if !isOneOfTypes(index, object.STRING, object.INT, object.BOOL) {
 			return &object.ErrorObject{
@@ -2,78 +2,60 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
+	"monkey/ast"
 	"monkey/object"
 )
 
-func evalInfixExpression(
-	scope *object.Scope,
-	operator string,
-	left, right object.Object,
-) object.Object {
-	resolvedLeft := resolveIdentIfNeeded(scope, left)
-	resolvedRight := resolveIdentIfNeeded(scope, right)
-
-	// Check for resolution errors (except for assignment where left stays as ident)
-	if operator != "=" {
-		if isType(object.ERROR, resolvedLeft) {
-			return resolvedLeft
-		}
-		if isType(object.ERROR, resolvedRight) {
-			return resolvedRight
+func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	// any arithmetic or comparison with a float is done on floats
+	switch operator {
+	case "+", "-", "*", "/", "%", "**", "<", ">", "<=", ">=", "==", "!=":
+		if isOneOfTypes(left, object.INT, object.FLOAT) &&
+			isOneOfTypes(right, object.INT, object.FLOAT) &&
+			(isType(object.FLOAT, left) || isType(object.FLOAT, right)) {
+			return evalFloatInfixExpression(operator, toFloat(left), toFloat(right))
 		}
 	}
 
 	switch operator {
-	case "=":
-		if !isType(object.IDENT, left) {
-			return makeIncorrectOperationError(operator, left, right)
-		}
-		ident, _ := left.(*object.IdentifierObject)
-		_, isDefined := scope.Get(ident.Value)
-		if !isDefined {
-			return &object.ErrorObject{
-				Message: &object.StringObject{Value: fmt.Sprintf("%s is not defined", ident.Value)},
-			}
-		}
-		scope.Set(ident.Value, right)
-		return right
 	case "+":
-		if !isOneOfTypes(resolvedLeft, object.STRING, object.INT) ||
-			!isOneOfTypes(resolvedRight, object.STRING, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.STRING, object.INT, object.FLOAT) ||
+			!isOneOfTypes(right, object.STRING, object.INT, object.FLOAT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		if isType(object.INT, resolvedLeft) && isType(object.INT, resolvedRight) {
-			leftInt, _ := resolvedLeft.(*object.IntObject)
-			rightInt, _ := resolvedRight.(*object.IntObject)
+		if isType(object.INT, left) && isType(object.INT, right) {
+			leftInt, _ := left.(*object.IntObject)
+			rightInt, _ := right.(*object.IntObject)
 			return &object.IntObject{Value: leftInt.Value + rightInt.Value}
 		}
-		return &object.StringObject{Value: resolvedLeft.Inspect() + resolvedRight.Inspect()}
+		return &object.StringObject{Value: left.Inspect() + right.Inspect()}
 	case "-":
-		if !isOneOfTypes(resolvedLeft, object.INT) || !isOneOfTypes(resolvedRight, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.INT) || !isOneOfTypes(right, object.INT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		leftInt, _ := resolvedLeft.(*object.IntObject)
-		rightInt, _ := resolvedRight.(*object.IntObject)
+		leftInt, _ := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
 		return &object.IntObject{Value: leftInt.Value - rightInt.Value}
 	case "*":
-		if !isOneOfTypes(resolvedLeft, object.STRING, object.INT) ||
-			!isOneOfTypes(resolvedRight, object.STRING, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.STRING, object.INT) ||
+			!isOneOfTypes(right, object.STRING, object.INT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		if isType(object.STRING, resolvedLeft) && isType(object.STRING, resolvedRight) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if isType(object.STRING, left) && isType(object.STRING, right) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		if isType(object.INT, resolvedLeft) && isType(object.INT, resolvedRight) {
-			leftInt, _ := resolvedLeft.(*object.IntObject)
-			rightInt, _ := resolvedRight.(*object.IntObject)
+		if isType(object.INT, left) && isType(object.INT, right) {
+			leftInt, _ := left.(*object.IntObject)
+			rightInt, _ := right.(*object.IntObject)
 			return &object.IntObject{Value: leftInt.Value * rightInt.Value}
 		}
-		leftInt, isLeftInt := resolvedLeft.(*object.IntObject)
-		rightInt, _ := resolvedRight.(*object.IntObject)
-		leftString, _ := resolvedLeft.(*object.StringObject)
-		rightString, _ := resolvedRight.(*object.StringObject)
+		leftInt, isLeftInt := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
+		leftString, _ := left.(*object.StringObject)
+		rightString, _ := right.(*object.StringObject)
 		if isLeftInt {
 			return &object.StringObject{
 				Value: strings.Repeat(rightString.Value, int(leftInt.Value)),
@@ -84,42 +66,49 @@ func evalInfixExpression(
 			}
 		}
 	case "/":
-		if !isOneOfTypes(resolvedLeft, object.INT) || !isOneOfTypes(resolvedRight, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.INT) || !isOneOfTypes(right, object.INT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		leftInt, _ := resolvedLeft.(*object.IntObject)
-		rightInt, _ := resolvedRight.(*object.IntObject)
+		leftInt, _ := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
+		if rightInt.Value == 0 {
+			return &object.ErrorObject{
+				Message: &object.StringObject{Value: "integer division by zero"},
+			}
+		}
+		// INT / INT is truncated towards zero, use floats to get fraction
 		return &object.IntObject{Value: leftInt.Value / rightInt.Value}
 	case "<":
-		if !isOneOfTypes(resolvedLeft, object.INT) || !isOneOfTypes(resolvedRight, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.INT) || !isOneOfTypes(right, object.INT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		leftInt, _ := resolvedLeft.(*object.IntObject)
-		rightInt, _ := resolvedRight.(*object.IntObject)
+		leftInt, _ := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
 		return makeBoolObject(leftInt.Value < rightInt.Value)
 
 	case ">":
-		if !isOneOfTypes(resolvedLeft, object.INT) || !isOneOfTypes(resolvedRight, object.INT) {
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		if !isOneOfTypes(left, object.INT) || !isOneOfTypes(right, object.INT) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
-		leftInt, _ := resolvedLeft.(*object.IntObject)
-		rightInt, _ := resolvedRight.(*object.IntObject)
+		leftInt, _ := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
 		return makeBoolObject(leftInt.Value > rightInt.Value)
-	case "&&":
-		return &object.BoolObject{
-			Value: convertToBoolish(resolvedLeft) && convertToBoolish(resolvedRight),
-		}
-	case "||":
-		return &object.BoolObject{
-			Value: convertToBoolish(resolvedLeft) || convertToBoolish(resolvedRight),
+	case "in":
+		return evalInExpression(left, right)
+	case "%", "**", "<=", ">=", "&", "|", "^", "<<", ">>":
+		if !isType(object.INT, left, right) {
+			return makeIncorrectOperationError(operator, left, right)
 		}
+		leftInt, _ := left.(*object.IntObject)
+		rightInt, _ := right.(*object.IntObject)
+		return evalIntInfixExpression(operator, leftInt.Value, rightInt.Value)
 	case "==":
-		leftBool, isLeftBool := resolvedLeft.(*object.BoolObject)
-		rightBool, isRightBool := resolvedRight.(*object.BoolObject)
-		leftInt, isLeftInt := resolvedLeft.(*object.IntObject)
-		rightInt, isRightInt := resolvedRight.(*object.IntObject)
-		leftString, isLeftString := resolvedLeft.(*object.StringObject)
-		rightString, isRightString := resolvedRight.(*object.StringObject)
+		leftBool, isLeftBool := left.(*object.BoolObject)
+		rightBool, isRightBool := right.(*object.BoolObject)
+		leftInt, isLeftInt := left.(*object.IntObject)
+		rightInt, isRightInt := right.(*object.IntObject)
+		leftString, isLeftString := left.(*object.StringObject)
+		rightString, isRightString := right.(*object.StringObject)
 		switch {
 		case (isLeftInt && isRightInt):
 			return &object.BoolObject{Value: leftInt.Value == rightInt.Value}
@@ -127,16 +116,19 @@ func evalInfixExpression(
 			return &object.BoolObject{Value: leftBool.Value == rightBool.Value}
 		case (isLeftString && isRightString):
 			return &object.BoolObject{Value: leftString.Value == rightString.Value}
+		case isType(object.NULL, left) || isType(object.NULL, right):
+			// anything can be checked for null, only null is equal to it
+			return makeBoolObject(isType(object.NULL, left) == isType(object.NULL, right))
 		default:
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+			return makeIncorrectOperationError(operator, left, right)
 		}
 	case "!=":
-		leftBool, isLeftBool := resolvedLeft.(*object.BoolObject)
-		rightBool, isRightBool := resolvedRight.(*object.BoolObject)
-		leftInt, isLeftInt := resolvedLeft.(*object.IntObject)
-		rightInt, isRightInt := resolvedRight.(*object.IntObject)
-		leftString, isLeftString := resolvedLeft.(*object.StringObject)
-		rightString, isRightString := resolvedRight.(*object.StringObject)
+		leftBool, isLeftBool := left.(*object.BoolObject)
+		rightBool, isRightBool := right.(*object.BoolObject)
+		leftInt, isLeftInt := left.(*object.IntObject)
+		rightInt, isRightInt := right.(*object.IntObject)
+		leftString, isLeftString := left.(*object.StringObject)
+		rightString, isRightString := right.(*object.StringObject)
 		switch {
 		case (isLeftInt && isRightInt):
 			return &object.BoolObject{Value: leftInt.Value != rightInt.Value}
@@ -144,10 +136,132 @@ func evalInfixExpression(
 			return &object.BoolObject{Value: leftBool.Value != rightBool.Value}
 		case (isLeftString && isRightString):
 			return &object.BoolObject{Value: leftString.Value != rightString.Value}
+		case isType(object.NULL, left) || isType(object.NULL, right):
+			return makeBoolObject(isType(object.NULL, left) != isType(object.NULL, right))
 		default:
-			return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+			return makeIncorrectOperationError(operator, left, right)
+		}
+	default:
+		return makeIncorrectOperationError(operator, left, right)
+	}
+}
+
+// evalLogicalExpression short-circuits '&&', '||' and '??': right operand
+// is evaluated only when left one does not decide the result. The deciding
+// operand itself is returned, not converted to bool:
+// 0 && x -> 0, 5 && "a" -> "a", "" || "default" -> "default".
+// '??' falls back only on null: 0 ?? 5 -> 0, null ?? 5 -> 5
+func evalLogicalExpression(scope *object.Scope, node *ast.InfixExpression) object.Object {
+	left := Eval(scope, node.Left)
+	if isInterrupted(left) {
+		return left
+	}
+	if node.Operator == "??" {
+		if !isType(object.NULL, left) {
+			return left
+		}
+	} else if convertToBoolish(left) == (node.Operator == "||") {
+		// false && x and true || x are decided by the left operand
+		return left
+	}
+
+	return Eval(scope, node.Right)
+}
+
+// evalIntInfixExpression handles integer only operators. Like '+' and '*'
+// they wrap around on overflow, '%' takes the sign of the left operand
+func evalIntInfixExpression(operator string, left, right int64) object.Object {
+	switch operator {
+	case "%":
+		if right == 0 {
+			return &object.ErrorObject{
+				Message: &object.StringObject{Value: "integer modulo by zero"},
+			}
+		}
+		return &object.IntObject{Value: left % right}
+	case "**":
+		// there is no integer result for negative exponent
+		if right < 0 {
+			return &object.FloatObject{Value: math.Pow(float64(left), float64(right))}
 		}
+		return &object.IntObject{Value: intPow(left, right)}
+	case "<=":
+		return makeBoolObject(left <= right)
+	case ">=":
+		return makeBoolObject(left >= right)
+	case "&":
+		return &object.IntObject{Value: left & right}
+	case "|":
+		return &object.IntObject{Value: left | right}
+	case "^":
+		return &object.IntObject{Value: left ^ right}
+	case "<<", ">>":
+		if right < 0 {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("negative shift count %d", right),
+				},
+			}
+		}
+		// shift by 64 or more gives 0, or -1 for '>>' of negative number
+		if operator == "<<" {
+			return &object.IntObject{Value: left << right}
+		}
+		return &object.IntObject{Value: left >> right}
+	default:
+		return makeIncorrectOperationError(
+			operator,
+			&object.IntObject{Value: left},
+			&object.IntObject{Value: right},
+		)
+	}
+}
+
+// intPow raises base to not negative exponent by squaring
+func intPow(base, exponent int64) int64 {
+	result := int64(1)
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			result *= base
+		}
+		base *= base
+		exponent >>= 1
+	}
+	return result
+}
+
+// evalFloatInfixExpression follows IEEE 754, so division by zero gives +Inf/-Inf/NaN
+func evalFloatInfixExpression(operator string, left, right float64) object.Object {
+	switch operator {
+	case "+":
+		return &object.FloatObject{Value: left + right}
+	case "-":
+		return &object.FloatObject{Value: left - right}
+	case "*":
+		return &object.FloatObject{Value: left * right}
+	case "/":
+		return &object.FloatObject{Value: left / right}
+	case "%":
+		return &object.FloatObject{Value: math.Mod(left, right)}
+	case "**":
+		return &object.FloatObject{Value: math.Pow(left, right)}
+	case "<":
+		return makeBoolObject(left < right)
+	case ">":
+		return makeBoolObject(left > right)
+	case "<=":
+		return makeBoolObject(left <= right)
+	case ">=":
+		return makeBoolObject(left >= right)
+	case "==":
+		return makeBoolObject(left == right)
+	case "!=":
+		return makeBoolObject(left != right)
 	default:
-		return makeIncorrectOperationError(operator, resolvedLeft, resolvedRight)
+		return makeIncorrectOperationError(
+			operator,
+			&object.FloatObject{Value: left},
+			&object.FloatObject{Value: right},
+		)
 	}
 }
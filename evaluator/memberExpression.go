@@ -0,0 +1,88 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalMemberExpression looks up property name as a string key of hash,
+// 'a.name' is 'a["name"]', so missing key is null too. 'a?.name' is also
+// null when 'a' is null
+func evalMemberExpression(scope *object.Scope, node *ast.MemberExpression) object.Object {
+	source := Eval(scope, node.Object)
+	if isInterrupted(source) {
+		return source
+	}
+	if node.Optional && isType(object.NULL, source) {
+		return object.NULL_OBJECT
+	}
+
+	hash, isHash := source.(*object.HashObject)
+	if !isHash {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf(
+					"cannot access field %s of %s, it is %s",
+					node.Property.Value,
+					node.Object.String(),
+					source.Type(),
+				),
+			},
+			Pos: node.Property.Pos(),
+		}
+	}
+	value, found := hash.Map[node.Property.Value]
+	if !found {
+		return object.NULL_OBJECT
+	}
+	return value
+}
+
+// evalMemberAssignment sets field of hash, new fields are added. Missing
+// field is null for compound assignment, same as for index assignment
+func evalMemberAssignment(
+	scope *object.Scope,
+	target *ast.MemberExpression,
+	operator string,
+	right ast.Expression,
+) object.Object {
+	source := Eval(scope, target.Object)
+	if isInterrupted(source) {
+		return source
+	}
+	hash, isHash := source.(*object.HashObject)
+	if !isHash {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf(
+					"cannot assign to field %s of %s, it is %s",
+					target.Property.Value,
+					target.Object.String(),
+					source.Type(),
+				),
+			},
+			Pos: target.Property.Pos(),
+		}
+	}
+	if hash.Frozen {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("cannot assign to field %s of frozen HASH", target.Property.Value),
+			},
+			Pos: target.Property.Pos(),
+		}
+	}
+
+	current, found := hash.Map[target.Property.Value]
+	if !found {
+		current = object.NULL_OBJECT
+	}
+	value := evalAssignedValue(scope, operator, current, right)
+	if isInterrupted(value) {
+		return value
+	}
+	hash.Map[target.Property.Value] = value
+	return value
+}
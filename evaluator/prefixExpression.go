@@ -5,6 +5,11 @@ import (
 )
 
 func evalPrefixExpression(operator string, value object.Object) object.Object {
+	// every value has truthiness, so '!' works on all of them
+	if operator == "!" {
+		return makeBoolObject(!convertToBoolish(value))
+	}
+
 	switch it := value.(type) {
 	case *object.IntObject:
 		switch operator {
@@ -12,8 +17,8 @@ func evalPrefixExpression(operator string, value object.Object) object.Object {
 			return it
 		case "-":
 			return &object.IntObject{Value: -it.Value}
-		case "!":
-			return &object.BoolObject{Value: !convertToBoolish(it)}
+		case "~":
+			return &object.IntObject{Value: ^it.Value}
 		default:
 			return &object.ErrorObject{
 				Message: &object.StringObject{
@@ -21,33 +26,36 @@ func evalPrefixExpression(operator string, value object.Object) object.Object {
 				},
 			}
 		}
-	case *object.BoolObject:
+	case *object.FloatObject:
 		switch operator {
-		case "!":
-			return &object.BoolObject{Value: !it.Value}
+		case "+":
+			return it
+		case "-":
+			return &object.FloatObject{Value: -it.Value}
 		default:
 			return &object.ErrorObject{
 				Message: &object.StringObject{
-					Value: "Unsupported operator: " + operator + " for Bools",
+					Value: "Unsupported operator: " + operator + " for Floats",
 				},
 			}
 		}
-	default:
+	case *object.BoolObject:
 		return &object.ErrorObject{
 			Message: &object.StringObject{
 				Value: "Unsupported operator: " + operator + " for Bools",
 			},
 		}
 	case *object.StringObject:
-		switch operator {
-		case "!":
-			return &object.BoolObject{Value: !convertToBoolish(it)}
-		default:
-			return &object.ErrorObject{
-				Message: &object.StringObject{
-					Value: "Unsupported operator: " + operator + " for Strings",
-				},
-			}
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: "Unsupported operator: " + operator + " for Strings",
+			},
+		}
+	default:
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: "Unsupported operator: " + operator + " for " + string(value.Type()),
+			},
 		}
 	}
 }
@@ -0,0 +1,81 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+func evalRangeExpression(scope *object.Scope, node *ast.RangeExpression) object.Object {
+	bounds := []ast.Expression{node.Start, node.Stop, node.Step}
+	names := []string{"start", "end", "step"}
+	values := []int64{0, 0, 1}
+
+	for i, bound := range bounds {
+		if bound == nil {
+			continue
+		}
+		value := Eval(scope, bound)
+		if isInterrupted(value) {
+			return value
+		}
+		intValue, isInt := value.(*object.IntObject)
+		if !isInt {
+			return &object.ErrorObject{
+				Message: &object.StringObject{
+					Value: fmt.Sprintf("range %s must be INT, but was %s", names[i], value.Type()),
+				},
+				Pos: bound.Pos(),
+			}
+		}
+		values[i] = intValue.Value
+	}
+
+	if values[2] == 0 {
+		return &object.ErrorObject{
+			Message: &object.StringObject{Value: "range step must not be 0"},
+			Pos:     node.Step.Pos(),
+		}
+	}
+	return &object.RangeObject{
+		Start:     values[0],
+		Stop:      values[1],
+		Step:      values[2],
+		Inclusive: node.Inclusive,
+	}
+}
+
+// evalInExpression tells if item is element of an array or range,
+// substring of a string or key of a hash
+func evalInExpression(item, collection object.Object) object.Object {
+	switch collection := collection.(type) {
+	case *object.RangeObject:
+		// same as for arrays, integral float equals to its int: 1.0 in 0..3
+		value, isIntegral := toIntegral(item)
+		return makeBoolObject(isIntegral && collection.Contains(value))
+	case *object.ArrayObject:
+		for _, element := range collection.Items {
+			if objectsEqual(item, element) {
+				return makeBoolObject(true)
+			}
+		}
+		return makeBoolObject(false)
+	case *object.StringObject:
+		substring, isString := item.(*object.StringObject)
+		if !isString {
+			return makeIncorrectOperationError("in", item, collection)
+		}
+		return makeBoolObject(strings.Contains(collection.Value, substring.Value))
+	case *object.HashObject:
+		key, ok := hashKey(item)
+		if !ok {
+			return makeIncorrectOperationError("in", item, collection)
+		}
+		_, found := collection.Map[key]
+		return makeBoolObject(found)
+	default:
+		return makeIncorrectOperationError("in", item, collection)
+	}
+}
@@ -0,0 +1,137 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalSliceExpression makes new array or string from part of array or
+// string, strings are sliced by characters. Like in Python bounds out of
+// range are clamped instead of being errors, negative ones count from the
+// end and negative step goes backwards: 'arr[::-1]' is reversed array
+func evalSliceExpression(scope *object.Scope, node *ast.SliceExpression) object.Object {
+	source := Eval(scope, node.Identifier)
+	if isInterrupted(source) {
+		return source
+	}
+	if node.Optional && isType(object.NULL, source) {
+		return object.NULL_OBJECT
+	}
+	if !isOneOfTypes(source, object.ARRAY, object.STRING) {
+		return &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("can slice only [%s, %s], but was %s", object.STRING, object.ARRAY, source.Type()),
+			},
+		}
+	}
+
+	start, err := evalSliceBound(scope, "start", node.Start)
+	if err != nil {
+		return err
+	}
+	stop, err := evalSliceBound(scope, "end", node.Stop)
+	if err != nil {
+		return err
+	}
+	stepBound, err := evalSliceBound(scope, "step", node.Step)
+	if err != nil {
+		return err
+	}
+	step := int64(1)
+	if stepBound != nil {
+		step = *stepBound
+	}
+	if step == 0 {
+		return &object.ErrorObject{
+			Message: &object.StringObject{Value: "slice step must not be 0"},
+			Pos:     node.Step.Pos(),
+		}
+	}
+
+	switch source := source.(type) {
+	case *object.ArrayObject:
+		items := []object.Object{}
+		for _, i := range slicePositions(int64(len(source.Items)), start, stop, step) {
+			items = append(items, source.Items[i])
+		}
+		return &object.ArrayObject{Items: items}
+	default:
+		runes := []rune(source.(*object.StringObject).Value)
+		sliced := []rune{}
+		for _, i := range slicePositions(int64(len(runes)), start, stop, step) {
+			sliced = append(sliced, runes[i])
+		}
+		return &object.StringObject{Value: string(sliced)}
+	}
+}
+
+// evalSliceBound evaluates optional part of slice, it is nil when the part
+// is missing or null. The second result is set for errors and for break,
+// continue or return
+func evalSliceBound(scope *object.Scope, name string, node ast.Expression) (*int64, object.Object) {
+	if node == nil {
+		return nil, nil
+	}
+	value := Eval(scope, node)
+	if isInterrupted(value) {
+		return nil, value
+	}
+	switch value := value.(type) {
+	case *object.IntObject:
+		return &value.Value, nil
+	case object.NullObject:
+		return nil, nil
+	default:
+		return nil, &object.ErrorObject{
+			Message: &object.StringObject{
+				Value: fmt.Sprintf("slice %s must be INT, but was %s", name, value.Type()),
+			},
+			Pos: node.Pos(),
+		}
+	}
+}
+
+// slicePositions returns positions of elements in slice of a sequence with
+// length elements, nil start and stop mean from the beginning and to the
+// end, or from the end and to the beginning for negative step
+func slicePositions(length int64, start, stop *int64, step int64) []int64 {
+	// bounds are clamped to [lower, upper], for negative step -1 means
+	// stop before the first element
+	lower, upper := int64(0), length
+	if step < 0 {
+		lower, upper = -1, length-1
+	}
+	clamp := func(bound *int64, missing int64) int64 {
+		if bound == nil {
+			return missing
+		}
+		value := *bound
+		if value < 0 {
+			value += length
+		}
+		return min(max(value, lower), upper)
+	}
+
+	var from, to, count int64
+	if step > 0 {
+		from, to = clamp(start, lower), clamp(stop, upper)
+		if from < to {
+			count = (to-from-1)/step + 1
+		}
+	} else {
+		from, to = clamp(start, upper), clamp(stop, lower)
+		if from > to {
+			// -step overflows for the smallest int64, division by it
+			// still gives 0 which is right as there is just one element
+			count = (from-to-1)/-step + 1
+		}
+	}
+
+	positions := make([]int64, 0, count)
+	for i := int64(0); i < count; i++ {
+		positions = append(positions, from+i*step)
+	}
+	return positions
+}
@@ -2,33 +2,60 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
 
 	"monkey/object"
 )
 
+// convertToBoolish tells if value counts as true in conditions, '!', '&&'
+// and '||':
+//
+//	INT, FLOAT        false only for 0 (and 0.0, -0.0)
+//	BOOL              its value
+//	STRING            false only for ""
+//	ARRAY, HASH       false only when empty
+//	RANGE             false only when empty
+//	NULL              false
+//	FN, BUILTIN_FN    true
+//
+// Anything else is true, so there is no value that cannot be tested
 func convertToBoolish(it object.Object) bool {
 	switch it := it.(type) {
 	case *object.IntObject:
-		switch it.Value {
-		case 0:
-			return false
-		default:
-			return true
-		}
+		return it.Value != 0
+	case *object.FloatObject:
+		return it.Value != 0
 	case *object.BoolObject:
 		return it.Value
 	case *object.StringObject:
-		if it.Value == "" {
-			return false
-		}
-		return true
+		return it.Value != ""
+	case *object.ArrayObject:
+		return len(it.Items) != 0
+	case *object.HashObject:
+		return len(it.Map) != 0
+	case *object.RangeObject:
+		return it.Len() != 0
+	case object.NullObject, nil:
+		return false
 	default:
-		panic(
-			fmt.Sprintf("you tried to convert to bool unknown type %T", it),
-		)
+		return true
 	}
 }
 
+// isPrintable tells if object can be turned into text by 'puts' or string interpolation
+func isPrintable(it object.Object) bool {
+	return isOneOfTypes(
+		it,
+		object.STRING,
+		object.INT,
+		object.FLOAT,
+		object.BOOL,
+		object.ARRAY,
+		object.HASH,
+		object.RANGE,
+	)
+}
+
 func isType(t object.ObjectType, actual ...object.Object) bool {
 	for i := range actual {
 		if actual[i] == nil || actual[i].Type() != t {
@@ -38,6 +65,13 @@ func isType(t object.ObjectType, actual ...object.Object) bool {
 	return true
 }
 
+// isInterrupted tells that evaluation stopped early, so the result must be
+// passed up instead of being used as a value. It is an error, or return,
+// break or continue from a block inside of an expression
+func isInterrupted(val object.Object) bool {
+	return isOneOfTypes(val, object.ERROR, object.RETURN, object.BREAK, object.CONTINUE)
+}
+
 func isOneOfTypes(val object.Object, types ...object.ObjectType) bool {
 	for i := range types {
 		if types[i] == val.Type() {
@@ -68,20 +102,92 @@ func makeBoolObject(val bool) *object.BoolObject {
 	}
 }
 
-func resolveIdentIfNeeded(scope *object.Scope, o object.Object) object.Object {
-	ident, isIdent := o.(*object.IdentifierObject)
-	if isIdent {
-		res, found := scope.Get(ident.Value)
-		if !found {
-			return &object.ErrorObject{
-				Message: &object.StringObject{
-					Value: fmt.Sprintf("identifier %s not found", ident.Value),
-				},
-			}
+// hashKey turns STRING, INT or BOOL object into Go value used as
+// HashObject key, other objects cannot be keys
+func hashKey(it object.Object) (any, bool) {
+	switch it := it.(type) {
+	case *object.StringObject:
+		return it.Value, true
+	case *object.IntObject:
+		return it.Value, true
+	case *object.BoolObject:
+		return it.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// objectsEqual compares numbers, bools, strings and nulls by value,
+// any other objects are equal only to themselves
+func objectsEqual(left, right object.Object) bool {
+	if isOneOfTypes(left, object.INT, object.FLOAT) && isOneOfTypes(right, object.INT, object.FLOAT) {
+		if isType(object.INT, left, right) {
+			return left.(*object.IntObject).Value == right.(*object.IntObject).Value
 		}
-		return res
+		return toFloat(left) == toFloat(right)
+	}
+	switch left := left.(type) {
+	case *object.BoolObject:
+		right, isBool := right.(*object.BoolObject)
+		return isBool && left.Value == right.Value
+	case *object.StringObject:
+		right, isString := right.(*object.StringObject)
+		return isString && left.Value == right.Value
+	case object.NullObject:
+		return isType(object.NULL, right)
+	default:
+		return left == right
+	}
+}
+
+// hashKeyToObject turns Go value used as HashObject key back into object
+func hashKeyToObject(key any) object.Object {
+	switch key := key.(type) {
+	case string:
+		return &object.StringObject{Value: key}
+	case int64:
+		return &object.IntObject{Value: key}
+	case bool:
+		return makeBoolObject(key)
+	default:
+		panic(fmt.Sprintf("unexpected hash key type %T", key))
+	}
+}
+
+// freezeObject makes array or hash and everything reachable from it
+// immutable, in place. Other objects cannot be changed anyway
+func freezeObject(it object.Object) {
+	switch it := it.(type) {
+	case *object.ArrayObject:
+		// already frozen ones are skipped, this also stops on cycles
+		if it.Frozen {
+			return
+		}
+		it.Frozen = true
+		for _, item := range it.Items {
+			freezeObject(item)
+		}
+	case *object.HashObject:
+		if it.Frozen {
+			return
+		}
+		it.Frozen = true
+		for _, value := range it.Map {
+			freezeObject(value)
+		}
+	}
+}
+
+// toFloat converts INT or FLOAT object to float64, caller checks the type
+func toFloat(it object.Object) float64 {
+	switch it := it.(type) {
+	case *object.IntObject:
+		return float64(it.Value)
+	case *object.FloatObject:
+		return it.Value
+	default:
+		panic(fmt.Sprintf("cannot convert %T to float", it))
 	}
-	return o
 }
 
 func types(objects []object.Object) []object.ObjectType {
@@ -91,3 +197,20 @@ func types(objects []object.Object) []object.ObjectType {
 	}
 	return res
 }
+
+// toIntegral returns value of INT, or of FLOAT without fraction which fits
+// into int64. Anything else is not integral
+func toIntegral(it object.Object) (int64, bool) {
+	switch it := it.(type) {
+	case *object.IntObject:
+		return it.Value, true
+	case *object.FloatObject:
+		// float64(math.MaxInt64) is 2^63, which is already out of int64
+		if it.Value != math.Trunc(it.Value) || it.Value < math.MinInt64 || it.Value >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(it.Value), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,30 @@
+package evaluator
+
+import (
+	"monkey/ast"
+	"monkey/object"
+)
+
+// evalWhileStatement runs body while condition is truthy, loop itself
+// evaluates to null
+func evalWhileStatement(scope *object.Scope, node *ast.WhileStatement) object.Object {
+	for {
+		condition := Eval(scope, node.Condition)
+		if isInterrupted(condition) {
+			return condition
+		}
+		if !convertToBoolish(condition) {
+			return object.NULL_OBJECT
+		}
+
+		// every iteration gets new block scope
+		result := Eval(scope, node.Body)
+		switch {
+		case isOneOfTypes(result, object.ERROR, object.RETURN):
+			return result
+		case isType(object.BREAK, result):
+			return object.NULL_OBJECT
+		}
+		// CONTINUE only cuts the body short
+	}
+}
@@ -0,0 +1,21 @@
+package lexer
+
+import (
+	"fmt"
+
+	"monkey/token"
+)
+
+// Error is a problem in the source text found by lexer
+type Error struct {
+	Pos token.Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+func (l *Lexer) errorf(pos token.Position, format string, args ...any) {
+	l.errors = append(l.errors, &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
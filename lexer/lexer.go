@@ -1,43 +1,111 @@
 package lexer
 
 import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"monkey/token"
 )
 
+// Lexer walks input rune by rune, currentPosition and peekPosition are
+// byte offsets of the current and the next rune.
 type Lexer struct {
+	filename        string
 	input           string
 	currentPosition int
-	currentChar     byte
+	currentChar     rune
 	peekPosition    int
+	// line and column (in runes) of currentChar, both start from 1
+	line   int
+	column int
+	// when false comments are skipped like whitespaces
+	keepComments bool
+	// stack of '${' the lexer is currently inside of, the innermost is the last
+	interpolations []interpolation
+	errors         []error
+}
+
+type interpolation struct {
+	// quote of the string to get back to once interpolation is closed
+	quote rune
+	// start of the string, used to report it if it is never closed
+	start token.Position
+	// depth of '{' opened inside of interpolation, like in '${ #{1: 2}[1] }'
+	braces int
 }
 
 func New(input string) *Lexer {
-	if len(input) <= 1 {
-		panic("Lexer must have input")
-	}
-	lexer := &Lexer{input: input}
-	lexer.currentPosition = 0
-	lexer.currentChar = input[0]
-	lexer.peekPosition = 1
+	return NewFile("", input)
+}
+
+// NewFile creates lexer which reports filename in positions of the tokens
+func NewFile(filename, input string) *Lexer {
+	lexer := &Lexer{filename: filename, input: input, line: 1, column: 1}
+	lexer.readChar()
 	return lexer
 }
 
-func isDigit(ch byte) bool {
-	return '0' <= ch && ch <= '9' || ch == '_'
+// Errors returns problems found in already read part of the input.
+// Every ILLEGAL token has an error here with the same position explaining it
+func (l *Lexer) Errors() []error {
+	return l.errors
+}
+
+// KeepComments makes NextToken return COMMENT tokens instead of skipping them
+func (l *Lexer) KeepComments(keep bool) {
+	l.keepComments = keep
+}
+
+func isDigit(ch rune) bool {
+	return isDecimal(ch) || ch == '_'
+}
+
+func isDecimal(ch rune) bool {
+	return '0' <= ch && ch <= '9'
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+// isLetter accepts unicode letters same as Go identifiers do
+func isLetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' ||
+		ch >= utf8.RuneSelf && unicode.IsLetter(ch)
+}
+
+// isIdentifierChar accepts letters and unicode digits, which are allowed
+// anywhere in identifier except the first character
+func isIdentifierChar(ch rune) bool {
+	return isLetter(ch) || isDigit(ch) || ch >= utf8.RuneSelf && unicode.IsDigit(ch)
 }
 
 func (l *Lexer) NextToken() token.Token {
-	var t token.Token
+	for {
+		l.skipWhitespaces()
 
-	l.skipWhitespaces()
+		start := l.position()
+		t := l.readToken()
+		t.Span = token.Span{Start: start, End: l.position()}
+		if t.Type == token.COMMENT && !l.keepComments {
+			continue
+		}
+		return t
+	}
+}
+
+func (l *Lexer) readToken() token.Token {
+	var t token.Token
 
 	switch l.currentChar {
 	case 0:
-		t = token.Empty()
+		if !l.isEOF() {
+			t = l.illegalChar()
+			break
+		}
+		if len(l.interpolations) > 0 {
+			l.errorf(l.interpolations[0].start, "unterminated string")
+			l.interpolations = nil
+		}
+		return token.Empty()
 
 	case '=':
 		if l.peekChar() == '=' {
@@ -46,17 +114,84 @@ func (l *Lexer) NextToken() token.Token {
 			second := string(l.nextChar())
 			literal := first + second
 			t = token.New(token.EQ, literal)
+		} else if l.peekChar() == '>' {
+			l.nextChar()
+			t = token.New(token.ARROW, "=>")
 		} else {
 			t = token.New(token.ASSIGN, string(l.currentChar))
 		}
 	case '+':
-		t = token.New(token.PLUS, string(l.currentChar))
+		if l.peekChar() == '=' {
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.PLUS_ASSIGN, literal)
+		} else {
+			t = token.New(token.PLUS, string(l.currentChar))
+		}
 	case '-':
-		t = token.New(token.MINUS, string(l.currentChar))
+		if l.peekChar() == '=' {
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.MINUS_ASSIGN, literal)
+		} else {
+			t = token.New(token.MINUS, string(l.currentChar))
+		}
 	case '*':
-		t = token.New(token.ASTERISK, string(l.currentChar))
+		switch l.peekChar() {
+		case '*':
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.POWER, literal)
+		case '=':
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.ASTERISK_ASSIGN, literal)
+		default:
+			t = token.New(token.ASTERISK, string(l.currentChar))
+		}
+	case '%':
+		if l.peekChar() == '=' {
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.PERCENT_ASSIGN, literal)
+		} else {
+			t = token.New(token.PERCENT, string(l.currentChar))
+		}
+	case '^':
+		t = token.New(token.BIT_XOR, string(l.currentChar))
+	case '~':
+		t = token.New(token.BIT_NOT, string(l.currentChar))
 	case '/':
-		t = token.New(token.SLASH, string(l.currentChar))
+		switch l.peekChar() {
+		case '/':
+			return token.New(token.COMMENT, l.readLineComment())
+		case '*':
+			start := l.position()
+			comment, terminated := l.readBlockComment()
+			if !terminated {
+				l.errorf(start, "unterminated block comment")
+				return token.New(token.ILLEGAL, comment)
+			}
+			return token.New(token.COMMENT, comment)
+		case '=':
+			first := string(l.currentChar)
+			// as this token is two-character, skip first one here
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.SLASH_ASSIGN, literal)
+		default:
+			t = token.New(token.SLASH, string(l.currentChar))
+		}
 	case '!':
 		if l.peekChar() == '=' {
 			first := string(l.currentChar)
@@ -75,41 +210,94 @@ func (l *Lexer) NextToken() token.Token {
 		t = token.New(token.HASH, string(l.currentChar))
 	case ':':
 		t = token.New(token.COLON, string(l.currentChar))
+	case '.':
+		if l.peekChar() != '.' {
+			t = token.New(token.DOT, ".")
+			break
+		}
+		l.nextChar()
+		switch l.peekChar() {
+		case '=':
+			l.nextChar()
+			t = token.New(token.DOT_DOT_EQ, "..=")
+		case '.':
+			l.nextChar()
+			t = token.New(token.ELLIPSIS, "...")
+		default:
+			t = token.New(token.DOT_DOT, "..")
+		}
+
+	case '?':
+		switch l.peekChar() {
+		case '?':
+			l.nextChar()
+			t = token.New(token.NULLISH, "??")
+		case '.':
+			l.nextChar()
+			t = token.New(token.OPTIONAL_DOT, "?.")
+		case '[':
+			l.nextChar()
+			t = token.New(token.OPTIONAL_LBRKT, "?[")
+		default:
+			t = l.illegalChar()
+		}
 
 	case '(':
 		t = token.New(token.LPAREN, string(l.currentChar))
 	case ')':
 		t = token.New(token.RPAREN, string(l.currentChar))
 	case '{':
+		if len(l.interpolations) > 0 {
+			l.interpolations[len(l.interpolations)-1].braces += 1
+		}
 		t = token.New(token.LBRACE, string(l.currentChar))
 	case '}':
+		if len(l.interpolations) > 0 {
+			current := &l.interpolations[len(l.interpolations)-1]
+			if current.braces == 0 {
+				return l.continueString()
+			}
+			current.braces -= 1
+		}
 		t = token.New(token.RBRACE, string(l.currentChar))
 	case '[':
 		t = token.New(token.LBRKT, string(l.currentChar))
 	case ']':
 		t = token.New(token.RBRKT, string(l.currentChar))
 	case '<':
-		if l.peekChar() == '=' {
+		switch l.peekChar() {
+		case '=':
 			first := string(l.currentChar)
 			// as this token is two-character, skip first one here
 			second := string(l.nextChar())
 			literal := first + second
 			t = token.New(token.LT_OR_EQ, literal)
-		} else {
+		case '<':
+			first := string(l.currentChar)
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.SHIFT_LEFT, literal)
+		default:
 			t = token.New(token.LT, string(l.currentChar))
 		}
 	case '>':
-		if l.peekChar() == '=' {
+		switch l.peekChar() {
+		case '=':
 			first := string(l.currentChar)
 			// as this token is two-character, skip first one here
 			second := string(l.nextChar())
 			literal := first + second
 			t = token.New(token.GT_OR_EQ, literal)
-		} else {
+		case '>':
+			first := string(l.currentChar)
+			second := string(l.nextChar())
+			literal := first + second
+			t = token.New(token.SHIFT_RIGHT, literal)
+		default:
 			t = token.New(token.GT, string(l.currentChar))
 		}
 	case '\'', '"', '`':
-		return token.New(token.STRING, l.readString())
+		return l.readString()
 
 	case '&':
 		if l.peekChar() == '&' {
@@ -118,6 +306,8 @@ func (l *Lexer) NextToken() token.Token {
 			second := string(l.nextChar())
 			literal := first + second
 			t = token.New(token.AND, literal)
+		} else {
+			t = token.New(token.BIT_AND, string(l.currentChar))
 		}
 	case '|':
 		if l.peekChar() == '|' {
@@ -126,12 +316,18 @@ func (l *Lexer) NextToken() token.Token {
 			second := string(l.nextChar())
 			literal := first + second
 			t = token.New(token.OR, literal)
+		} else if l.peekChar() == '>' {
+			l.nextChar()
+			t = token.New(token.PIPE, "|>")
+		} else {
+			t = token.New(token.BIT_OR, string(l.currentChar))
 		}
 
 	default:
-		if isDigit(l.currentChar) {
-			number := l.readNumber()
-			return token.New(token.INT, number)
+		// '_' is allowed inside of the number, but starts an identifier
+		if isDecimal(l.currentChar) {
+			number, numberType := l.readNumber()
+			return token.New(numberType, number)
 		}
 
 		if isLetter(l.currentChar) {
@@ -140,73 +336,353 @@ func (l *Lexer) NextToken() token.Token {
 			return token.New(t, identifier)
 		}
 
-		t = token.New(token.ILLEGAL, string(l.currentChar))
+		t = l.illegalChar()
 	}
 
 	l.nextChar()
 	return t
 }
 
-func (this *Lexer) nextChar() byte {
-	if this.peekPosition >= len(this.input) {
-		this.currentChar = 0
+// illegalChar reports current character and makes ILLEGAL token of it,
+// caller still has to go over it
+func (this *Lexer) illegalChar() token.Token {
+	literal := this.input[this.currentPosition:this.peekPosition]
+	reason := fmt.Sprintf("unexpected character %q", this.currentChar)
+	if this.currentChar == utf8.RuneError && len(literal) == 1 {
+		reason = fmt.Sprintf("invalid UTF-8 byte %#x", literal[0])
+	}
+	this.errorf(this.position(), "%s", reason)
+	return token.New(token.ILLEGAL, literal)
+}
+
+func (this *Lexer) isEOF() bool {
+	return this.currentPosition >= len(this.input)
+}
+
+func (this *Lexer) nextChar() rune {
+	if this.isEOF() {
+		return this.currentChar
+	}
+	if this.currentChar == '\n' {
+		this.line += 1
+		this.column = 1
 	} else {
-		this.currentChar = this.input[this.peekPosition]
+		this.column += 1
 	}
+	return this.readChar()
+}
+
+// readChar decodes rune at peekPosition, invalid utf-8 is read
+// byte by byte as utf8.RuneError
+func (this *Lexer) readChar() rune {
 	this.currentPosition = this.peekPosition
-	this.peekPosition += 1
+	if this.peekPosition >= len(this.input) {
+		this.currentChar = 0
+		return this.currentChar
+	}
+	char, width := utf8.DecodeRuneInString(this.input[this.peekPosition:])
+	this.currentChar = char
+	this.peekPosition += width
 	return this.currentChar
 }
 
-func (this *Lexer) peekChar() byte {
+func (this *Lexer) position() token.Position {
+	return token.Position{
+		Filename: this.filename,
+		Offset:   this.currentPosition,
+		Line:     this.line,
+		Column:   this.column,
+	}
+}
+
+func (this *Lexer) peekChar() rune {
 	if this.peekPosition >= len(this.input) {
 		return 0
-	} else {
-		return this.input[this.peekPosition]
 	}
+	char, _ := utf8.DecodeRuneInString(this.input[this.peekPosition:])
+	return char
 }
 
 func (this *Lexer) readIdentifier() string {
 	position := this.currentPosition
-	for isLetter(this.currentChar) || isDigit(this.currentChar) {
+	for isIdentifierChar(this.currentChar) {
 		this.nextChar()
 	}
 	return this.input[position:this.currentPosition]
 }
 
-func (this *Lexer) readNumber() string {
+// readNumber reads INT like '10_000', '0xFF', '0o755', '0b1010' or FLOAT like
+// '3.14', '1e-9', '1_000.5e3'. Fraction needs digits on both sides of the '.',
+// so '1..2' and '1.foo' stay INT followed by other tokens
+func (this *Lexer) readNumber() (string, token.TokenType) {
+	start := this.position()
 	position := this.currentPosition
+	var numberType token.TokenType = token.INT
+
+	if this.currentChar == '0' && strings.ContainsRune("xXoObB", this.peekChar()) {
+		// digits are validated by parser, so '0b102' or '0xZ' are read as a
+		// single literal and reported as a whole
+		this.nextChar()
+		this.nextChar()
+		for isIdentifierChar(this.currentChar) {
+			this.nextChar()
+		}
+		return this.input[position:this.currentPosition], numberType
+	}
+
 	for isDigit(this.currentChar) {
 		this.nextChar()
 	}
-	return this.input[position:this.currentPosition]
+
+	if this.currentChar == '.' && isDecimal(this.peekChar()) {
+		numberType = token.FLOAT
+		this.nextChar()
+		for isDigit(this.currentChar) {
+			this.nextChar()
+		}
+	}
+
+	if this.currentChar == 'e' || this.currentChar == 'E' {
+		// exponent is taken only when it has digits, otherwise 'e' is left
+		// for the next token, so roll back to it
+		saved := *this
+		this.nextChar()
+		if this.currentChar == '+' || this.currentChar == '-' {
+			this.nextChar()
+		}
+		if isDecimal(this.currentChar) {
+			numberType = token.FLOAT
+			for isDigit(this.currentChar) {
+				this.nextChar()
+			}
+		} else {
+			*this = saved
+		}
+	}
+
+	number := this.input[position:this.currentPosition]
+	this.checkSeparators(start, number)
+	return number, numberType
 }
 
-func (this *Lexer) readString() string {
-	openingQuote := this.currentChar
-	acc := ""
+// checkSeparators reports '_' which is not between two digits of decimal
+// number, like in '1_.5', '1_e3', '1__0' or '10_'
+func (this *Lexer) checkSeparators(start token.Position, number string) {
+	for i := 0; i < len(number); i++ {
+		if number[i] != '_' {
+			continue
+		}
+		if i+1 < len(number) && isDecimal(rune(number[i-1])) && isDecimal(rune(number[i+1])) {
+			continue
+		}
+		// number is ASCII, so offset in it is the column offset too
+		pos := start
+		pos.Offset += i
+		pos.Column += i
+		this.errorf(pos, "'_' must separate digits in number %s", number)
+		return
+	}
+}
 
-	// go over to first string byte
+// readString reads string from the opening quote up to the closing one,
+// or up to the '${' if string is interpolated
+func (this *Lexer) readString() token.Token {
+	start := this.position()
+	quote := this.currentChar
+	// go over to first string character
 	this.nextChar()
 
-	for this.currentChar != openingQuote && this.currentChar != 0 {
-		if !this.isEscapeChar() {
-			acc += string(this.currentChar)
-			this.nextChar()
-		} else {
+	text, interpolated, terminated := this.readStringPart(quote, start)
+	if !terminated {
+		return this.unterminatedString(start)
+	}
+	if interpolated {
+		return token.New(token.STRING_HEAD, text)
+	}
+	return token.New(token.STRING, text)
+}
+
+// continueString reads rest of the string after '}' closing the interpolation
+func (this *Lexer) continueString() token.Token {
+	current := this.interpolations[len(this.interpolations)-1]
+	this.interpolations = this.interpolations[:len(this.interpolations)-1]
+	// go over '}'
+	this.nextChar()
+
+	text, interpolated, terminated := this.readStringPart(current.quote, current.start)
+	if !terminated {
+		return this.unterminatedString(current.start)
+	}
+	if interpolated {
+		return token.New(token.STRING_MIDDLE, text)
+	}
+	return token.New(token.STRING_TAIL, text)
+}
+
+// unterminatedString reports string and makes ILLEGAL token of its source text
+func (this *Lexer) unterminatedString(start token.Position) token.Token {
+	this.errorf(start, "unterminated string")
+	return token.New(token.ILLEGAL, this.input[start.Offset:this.currentPosition])
+}
+
+// readStringPart reads text till the closing quote, which is skipped, or
+// till the '${', which is skipped as well and starts new interpolation.
+// Backtick strings are raw: no escapes and no interpolation
+func (this *Lexer) readStringPart(
+	quote rune,
+	start token.Position,
+) (text string, interpolated bool, terminated bool) {
+	acc := strings.Builder{}
+	raw := quote == '`'
+
+	for this.currentChar != quote && !this.isEOF() {
+		if !raw && this.currentChar == '$' && this.peekChar() == '{' {
 			this.nextChar()
-			acc += string(this.currentChar)
 			this.nextChar()
+			this.interpolations = append(
+				this.interpolations,
+				interpolation{quote: quote, start: start},
+			)
+			return acc.String(), true, true
+		}
+		if !raw && this.isEscapeChar() {
+			this.readEscape(&acc)
+			continue
 		}
+		// copy source bytes as is, so text survives even if it is not valid utf-8
+		acc.WriteString(this.input[this.currentPosition:this.peekPosition])
+		this.nextChar()
+	}
+	if this.isEOF() {
+		return acc.String(), false, false
 	}
 	// go over last quote
 	this.nextChar()
 
-	return acc
+	return acc.String(), false, true
+}
+
+// readLineComment reads '// ...' up to the end of the line, new line itself is not included
+func (this *Lexer) readLineComment() string {
+	position := this.currentPosition
+	for this.currentChar != '\n' && !this.isEOF() {
+		this.nextChar()
+	}
+	return this.input[position:this.currentPosition]
+}
+
+// readBlockComment reads '/* ... */', nested block comments are allowed,
+// so '/* a /* b */ c */' is a single comment
+func (this *Lexer) readBlockComment() (comment string, terminated bool) {
+	position := this.currentPosition
+	depth := 0
+	for !this.isEOF() {
+		if this.currentChar == '/' && this.peekChar() == '*' {
+			depth += 1
+			this.nextChar()
+		} else if this.currentChar == '*' && this.peekChar() == '/' {
+			depth -= 1
+			this.nextChar()
+		}
+		this.nextChar()
+		if depth == 0 {
+			return this.input[position:this.currentPosition], true
+		}
+	}
+	return this.input[position:this.currentPosition], false
+}
+
+var simpleEscapes = map[rune]string{
+	'n':  "\n",
+	't':  "\t",
+	'r':  "\r",
+	'0':  "\x00",
+	'\\': "\\",
+	'"':  "\"",
+	'\'': "'",
+	'$':  "$",
+}
+
+// readEscape reads escape sequence starting at '\\' and writes its value to acc.
+// Invalid sequences are reported and skipped, so the rest of the string is read as usual
+func (this *Lexer) readEscape(acc *strings.Builder) {
+	start := this.position()
+	// go over '\\'
+	this.nextChar()
+	if this.isEOF() {
+		// unterminated string, nothing to escape
+		return
+	}
+
+	if value, ok := simpleEscapes[this.currentChar]; ok {
+		acc.WriteString(value)
+		this.nextChar()
+		return
+	}
+
+	switch this.currentChar {
+	case 'x':
+		// '\\xNN' is a single byte, same as in Go
+		this.nextChar()
+		value, digits := this.readHexDigits(2)
+		if digits != 2 {
+			this.errorf(start, "invalid escape sequence, '\\x' must be followed by two hex digits")
+			return
+		}
+		acc.WriteByte(byte(value))
+	case 'u':
+		// '\\u{1F600}' is unicode code point of up to six hex digits
+		this.nextChar()
+		if this.currentChar != '{' {
+			this.errorf(start, "invalid escape sequence, '\\u' must be followed by '{'")
+			return
+		}
+		this.nextChar()
+		value, digits := this.readHexDigits(6)
+		if digits == 0 || this.currentChar != '}' {
+			this.errorf(start, "invalid escape sequence, '\\u{' must be followed by up to six hex digits and '}'")
+			return
+		}
+		this.nextChar()
+		if value > unicode.MaxRune || 0xD800 <= value && value <= 0xDFFF {
+			this.errorf(start, "invalid escape sequence, U+%X is not a valid code point", value)
+			return
+		}
+		acc.WriteRune(rune(value))
+	default:
+		this.errorf(start, "unknown escape sequence '\\%c'", this.currentChar)
+		acc.WriteString(this.input[this.currentPosition:this.peekPosition])
+		this.nextChar()
+	}
+}
+
+// readHexDigits reads up to max hex digits and returns their value and count
+func (this *Lexer) readHexDigits(max int) (value int, digits int) {
+	for digits < max && isHex(this.currentChar) {
+		value = value*16 + hexValue(this.currentChar)
+		digits += 1
+		this.nextChar()
+	}
+	return value, digits
+}
+
+func isHex(ch rune) bool {
+	return isDecimal(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func hexValue(ch rune) int {
+	switch {
+	case isDecimal(ch):
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch - 'a' + 10)
+	default:
+		return int(ch - 'A' + 10)
+	}
 }
 
 func (this *Lexer) isEscapeChar() bool {
-	return this.currentChar == byte('\\')
+	return this.currentChar == '\\'
 }
 
 func (this *Lexer) skipWhitespaces() {
@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"monkey/token"
@@ -27,6 +29,21 @@ func verifyTokens(t *testing.T, input string, expected []expectedToken) {
 	}
 }
 
+// verifyErrors reads whole input and checks errors reported on the way
+func verifyErrors(t *testing.T, input string, expected ...string) {
+	t.Helper()
+	l := New(input)
+	for l.NextToken().Type != token.EOF {
+	}
+	errors := []string{}
+	for _, err := range l.Errors() {
+		errors = append(errors, err.Error())
+	}
+	if !slices.Equal(errors, expected) {
+		t.Errorf("wrong errors. expected=%q, got=%q", expected, errors)
+	}
+}
+
 func TestNextToken_LetStatements(t *testing.T) {
 	input := `let five = 5;
 	let ten = 10;`
@@ -48,6 +65,21 @@ func TestNextToken_LetStatements(t *testing.T) {
 	verifyTokens(t, input, expected)
 }
 
+func TestNextToken_ConstStatement(t *testing.T) {
+	input := `const max = 10;`
+
+	expected := []expectedToken{
+		{token.CONST, "const"},
+		{token.IDENTIFIER, "max"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
 func TestNextToken_FunctionDefinition(t *testing.T) {
 	input := `let add = fn(x, y) {
 		x + y;
@@ -127,6 +159,181 @@ func TestNextToken_IfElseStatement(t *testing.T) {
 	verifyTokens(t, input, expected)
 }
 
+func TestNextToken_WhileLoop(t *testing.T) {
+	input := `while (x) { break; continue; } whiles`
+
+	expected := []expectedToken{
+		{token.WHILE, "while"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.BREAK, "break"},
+		{token.SEMICOLON, ";"},
+		{token.CONTINUE, "continue"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.IDENTIFIER, "whiles"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_ForLoop(t *testing.T) {
+	input := `for (k, v in items) {} index`
+
+	expected := []expectedToken{
+		{token.FOR, "for"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "k"},
+		{token.COMMA, ","},
+		{token.IDENTIFIER, "v"},
+		{token.IN, "in"},
+		{token.IDENTIFIER, "items"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.RBRACE, "}"},
+		{token.IDENTIFIER, "index"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_Ranges(t *testing.T) {
+	input := `0..10 1..=n 1.5..2 x in a . b`
+
+	expected := []expectedToken{
+		{token.INT, "0"},
+		{token.DOT_DOT, ".."},
+		{token.INT, "10"},
+		{token.INT, "1"},
+		{token.DOT_DOT_EQ, "..="},
+		{token.IDENTIFIER, "n"},
+		{token.FLOAT, "1.5"},
+		{token.DOT_DOT, ".."},
+		{token.INT, "2"},
+		{token.IDENTIFIER, "x"},
+		{token.IN, "in"},
+		{token.IDENTIFIER, "a"},
+		{token.DOT, "."},
+		{token.IDENTIFIER, "b"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_NullSafeOperators(t *testing.T) {
+	input := `null cfg?["db"]?.host ?? x ? y`
+
+	expected := []expectedToken{
+		{token.NULL, "null"},
+		{token.IDENTIFIER, "cfg"},
+		{token.OPTIONAL_LBRKT, "?["},
+		{token.STRING, "db"},
+		{token.RBRKT, "]"},
+		{token.OPTIONAL_DOT, "?."},
+		{token.IDENTIFIER, "host"},
+		{token.NULLISH, "??"},
+		{token.IDENTIFIER, "x"},
+		{token.ILLEGAL, "?"},
+		{token.IDENTIFIER, "y"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_Arrow(t *testing.T) {
+	input := `(a, b) => a == b; x =>x = >`
+
+	expected := []expectedToken{
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "a"},
+		{token.COMMA, ","},
+		{token.IDENTIFIER, "b"},
+		{token.RPAREN, ")"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIER, "a"},
+		{token.EQ, "=="},
+		{token.IDENTIFIER, "b"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIER, "x"},
+		{token.ASSIGN, "="},
+		{token.GT, ">"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_FnParameters(t *testing.T) {
+	input := `fn(a, b = 10, ...rest) 0..=1...`
+
+	expected := []expectedToken{
+		{token.FUNCTION, "fn"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "a"},
+		{token.COMMA, ","},
+		{token.IDENTIFIER, "b"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.COMMA, ","},
+		{token.ELLIPSIS, "..."},
+		{token.IDENTIFIER, "rest"},
+		{token.RPAREN, ")"},
+		{token.INT, "0"},
+		{token.DOT_DOT_EQ, "..="},
+		{token.INT, "1"},
+		{token.ELLIPSIS, "..."},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_Pipe(t *testing.T) {
+	input := `xs |> map(f) || a | b |>`
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "xs"},
+		{token.PIPE, "|>"},
+		{token.IDENTIFIER, "map"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "f"},
+		{token.RPAREN, ")"},
+		{token.OR, "||"},
+		{token.IDENTIFIER, "a"},
+		{token.BIT_OR, "|"},
+		{token.IDENTIFIER, "b"},
+		{token.PIPE, "|>"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_CompoundAssignment(t *testing.T) {
+	input := `x += 1; x -= 2; x *= 3; x /= 4; x %= 5; x ** 2; x - -1`
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "x"}, {token.PLUS_ASSIGN, "+="}, {token.INT, "1"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.MINUS_ASSIGN, "-="}, {token.INT, "2"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.ASTERISK_ASSIGN, "*="}, {token.INT, "3"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.SLASH_ASSIGN, "/="}, {token.INT, "4"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.PERCENT_ASSIGN, "%="}, {token.INT, "5"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.POWER, "**"}, {token.INT, "2"}, {token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"}, {token.MINUS, "-"}, {token.MINUS, "-"}, {token.INT, "1"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
 func TestNextToken_ComparisonOperators(t *testing.T) {
 	input := `10 == 10
 	9 != 11
@@ -169,14 +376,17 @@ func TestNextToken_String(t *testing.T) {
 }
 
 func TestNextToken_UnterminatedString(t *testing.T) {
-	input := "'hello mom"
+	input := "x + 'hello mom"
 
 	expected := []expectedToken{
-		{token.STRING, "hello mom"},
+		{token.IDENTIFIER, "x"},
+		{token.PLUS, "+"},
+		{token.ILLEGAL, "'hello mom"},
 		{token.EOF, ""},
 	}
 
 	verifyTokens(t, input, expected)
+	verifyErrors(t, input, "1:5: unterminated string")
 }
 
 func TestNextToken_LogicalOperators(t *testing.T) {
@@ -235,3 +445,444 @@ func TestNextToken_Hash(t *testing.T) {
 
 	verifyTokens(t, input, expected)
 }
+
+func TestNextToken_Positions(t *testing.T) {
+	input := "let x = 5;\n  x + 10"
+
+	expected := []token.Span{
+		{Start: token.Position{Offset: 0, Line: 1, Column: 1}, End: token.Position{Offset: 3, Line: 1, Column: 4}},
+		{Start: token.Position{Offset: 4, Line: 1, Column: 5}, End: token.Position{Offset: 5, Line: 1, Column: 6}},
+		{Start: token.Position{Offset: 6, Line: 1, Column: 7}, End: token.Position{Offset: 7, Line: 1, Column: 8}},
+		{Start: token.Position{Offset: 8, Line: 1, Column: 9}, End: token.Position{Offset: 9, Line: 1, Column: 10}},
+		{Start: token.Position{Offset: 9, Line: 1, Column: 10}, End: token.Position{Offset: 10, Line: 1, Column: 11}},
+		{Start: token.Position{Offset: 13, Line: 2, Column: 3}, End: token.Position{Offset: 14, Line: 2, Column: 4}},
+		{Start: token.Position{Offset: 15, Line: 2, Column: 5}, End: token.Position{Offset: 16, Line: 2, Column: 6}},
+		{Start: token.Position{Offset: 17, Line: 2, Column: 7}, End: token.Position{Offset: 19, Line: 2, Column: 9}},
+		{Start: token.Position{Offset: 19, Line: 2, Column: 9}, End: token.Position{Offset: 19, Line: 2, Column: 9}},
+	}
+
+	l := New(input)
+	for i, span := range expected {
+		tok := l.NextToken()
+		if tok.Span != span {
+			t.Errorf("token[%d] %q: wrong span. expected=%+v, got=%+v", i, tok.Literal, span, tok.Span)
+		}
+	}
+}
+
+func TestNextToken_PositionFilename(t *testing.T) {
+	l := NewFile("main.monkey", "\n\nfoo")
+	tok := l.NextToken()
+	if tok.Span.Start.String() != "main.monkey:3:1" {
+		t.Errorf("wrong position. expected=%q, got=%q", "main.monkey:3:1", tok.Span.Start.String())
+	}
+}
+
+func TestNextToken_CommentsAreSkipped(t *testing.T) {
+	input := `// leading comment
+	let x = 10 / 2; // trailing comment
+	/* block
+	   comment */ x /* inline */ + 1
+	/* outer /* nested */ still outer */
+	x`
+
+	expected := []expectedToken{
+		{token.LET, "let"},
+		{token.IDENTIFIER, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "x"},
+		{token.PLUS, "+"},
+		{token.INT, "1"},
+		{token.IDENTIFIER, "x"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_KeepComments(t *testing.T) {
+	input := `x // line
+	/* a /* b */ c */ y
+	// last`
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "x"},
+		{token.COMMENT, "// line"},
+		{token.COMMENT, "/* a /* b */ c */"},
+		{token.IDENTIFIER, "y"},
+		{token.COMMENT, "// last"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	l.KeepComments(true)
+	for i, tt := range expected {
+		tok := l.NextToken()
+		if tok.Type != tt.typ || tok.Literal != tt.lit {
+			t.Errorf("token[%d]: expected=%q %q, got=%q %q", i, tt.typ, tt.lit, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_UnterminatedBlockComment(t *testing.T) {
+	input := "x /* never /* closed */"
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "x"},
+		{token.ILLEGAL, "/* never /* closed */"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+	verifyErrors(t, input, "1:3: unterminated block comment")
+}
+
+func TestNextToken_UnicodeIdentifiers(t *testing.T) {
+	input := `let größe = 1; let 名前 = привет2; _ß٣`
+
+	expected := []expectedToken{
+		{token.LET, "let"},
+		{token.IDENTIFIER, "größe"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.LET, "let"},
+		{token.IDENTIFIER, "名前"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIER, "привет2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "_ß٣"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_UnicodeStrings(t *testing.T) {
+	input := "'😀 party' \"日本語\" `é vs é` '\\😀'"
+
+	expected := []expectedToken{
+		{token.STRING, "😀 party"},
+		{token.STRING, "日本語"},
+		{token.STRING, "é vs é"},
+		{token.STRING, "😀"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_NonLetterRunesAreIllegal(t *testing.T) {
+	input := "x 😀 y"
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "x"},
+		{token.ILLEGAL, "😀"},
+		{token.IDENTIFIER, "y"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_UnicodeColumns(t *testing.T) {
+	l := New("'日本' x")
+	l.NextToken()
+	tok := l.NextToken()
+	if tok.Span.Start.Column != 6 || tok.Span.Start.Offset != 9 {
+		t.Errorf("wrong position of %q. expected column=6 offset=9, got=%+v", tok.Literal, tok.Span.Start)
+	}
+}
+
+func TestNextToken_Numbers(t *testing.T) {
+	input := `5 3.14 1e-9 2E+3 1_000.5 6.02e23 1..2 3.len 7e x`
+
+	expected := []expectedToken{
+		{token.INT, "5"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1e-9"},
+		{token.FLOAT, "2E+3"},
+		{token.FLOAT, "1_000.5"},
+		{token.FLOAT, "6.02e23"},
+		{token.INT, "1"},
+		{token.DOT_DOT, ".."},
+		{token.INT, "2"},
+		{token.INT, "3"},
+		{token.DOT, "."},
+		{token.IDENTIFIER, "len"},
+		{token.INT, "7"},
+		{token.IDENTIFIER, "e"},
+		{token.IDENTIFIER, "x"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_NumberSeparators(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"1_000_000", ""},
+		{"1_000.5_5e1_0", ""},
+		{"1_.5", "1:2: '_' must separate digits in number 1_.5"},
+		{"1_e3", "1:2: '_' must separate digits in number 1_e3"},
+		{"x = 10_", "1:7: '_' must separate digits in number 10_"},
+		{"1__0", "1:2: '_' must separate digits in number 1__0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if tt.err == "" {
+				verifyErrors(t, tt.input)
+			} else {
+				verifyErrors(t, tt.input, tt.err)
+			}
+		})
+	}
+}
+
+func TestNextToken_PrefixedIntegers(t *testing.T) {
+	input := `0xFF 0o755 0b1010_1010 0XdeadBEEF 0b102 0x 0.5`
+
+	expected := []expectedToken{
+		{token.INT, "0xFF"},
+		{token.INT, "0o755"},
+		{token.INT, "0b1010_1010"},
+		{token.INT, "0XdeadBEEF"},
+		{token.INT, "0b102"},
+		{token.INT, "0x"},
+		{token.FLOAT, "0.5"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_InterpolatedString(t *testing.T) {
+	input := `"count: ${n} of ${total + 1}!" '${#{"a": 1}["a"]}' "\${x} $y" "${"in ${x}"}"`
+
+	expected := []expectedToken{
+		{token.STRING_HEAD, "count: "},
+		{token.IDENTIFIER, "n"},
+		{token.STRING_MIDDLE, " of "},
+		{token.IDENTIFIER, "total"},
+		{token.PLUS, "+"},
+		{token.INT, "1"},
+		{token.STRING_TAIL, "!"},
+
+		{token.STRING_HEAD, ""},
+		{token.HASH, "#"},
+		{token.LBRACE, "{"},
+		{token.STRING, "a"},
+		{token.COLON, ":"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.LBRKT, "["},
+		{token.STRING, "a"},
+		{token.RBRKT, "]"},
+		{token.STRING_TAIL, ""},
+
+		{token.STRING, "${x} $y"},
+
+		{token.STRING_HEAD, ""},
+		{token.STRING_HEAD, "in "},
+		{token.IDENTIFIER, "x"},
+		{token.STRING_TAIL, ""},
+		{token.STRING_TAIL, ""},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_EscapeSequences(t *testing.T) {
+	input := `"a\nb" 'tab\there' "\r\\\"\'\0" "\x41\x7a" "\u{1F600} \u{e9}" "\${x}"`
+
+	expected := []expectedToken{
+		{token.STRING, "a\nb"},
+		{token.STRING, "tab\there"},
+		{token.STRING, "\r\\\"'\x00"},
+		{token.STRING, "Az"},
+		{token.STRING, "😀 é"},
+		{token.STRING, "${x}"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+	verifyErrors(t, input)
+}
+
+func TestNextToken_RawStrings(t *testing.T) {
+	input := "`C:\\new\\${dir}\n  second line` x"
+
+	expected := []expectedToken{
+		{token.STRING, "C:\\new\\${dir}\n  second line"},
+		{token.IDENTIFIER, "x"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+}
+
+func TestNextToken_InvalidEscapeSequences(t *testing.T) {
+	tests := []struct {
+		input   string
+		literal string
+		err     string
+	}{
+		{`"a\qb"`, "aqb", `1:3: unknown escape sequence '\q'`},
+		{`"\x4"`, "", `1:2: invalid escape sequence, '\x' must be followed by two hex digits`},
+		{`"\xZZ"`, "ZZ", `1:2: invalid escape sequence, '\x' must be followed by two hex digits`},
+		{`"\u41"`, "41", `1:2: invalid escape sequence, '\u' must be followed by '{'`},
+		{`"\u{}"`, "}", `1:2: invalid escape sequence, '\u{' must be followed by up to six hex digits and '}'`},
+		{`"\u{1234567}"`, "7}", `1:2: invalid escape sequence, '\u{' must be followed by up to six hex digits and '}'`},
+		{`"\u{D800}"`, "", `1:2: invalid escape sequence, U+D800 is not a valid code point`},
+		{`"\u{110000}"`, "", `1:2: invalid escape sequence, U+110000 is not a valid code point`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			l := New(tt.input)
+			tok := l.NextToken()
+			if tok.Type != token.STRING || tok.Literal != tt.literal {
+				t.Errorf("wrong token. expected=STRING %q, got=%s %q", tt.literal, tok.Type, tok.Literal)
+			}
+			if len(l.Errors()) != 1 || l.Errors()[0].Error() != tt.err {
+				t.Errorf("wrong errors. expected=%q, got=%v", tt.err, l.Errors())
+			}
+		})
+	}
+}
+
+func TestNextToken_IllegalCharacters(t *testing.T) {
+	input := "a @ b $ c"
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "a"},
+		{token.ILLEGAL, "@"},
+		{token.IDENTIFIER, "b"},
+		{token.ILLEGAL, "$"},
+		{token.IDENTIFIER, "c"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+	verifyErrors(t, input,
+		"1:3: unexpected character '@'",
+		"1:7: unexpected character '$'",
+	)
+}
+
+func TestNextToken_ArithmeticAndBitwiseOperators(t *testing.T) {
+	input := "a % b ** c & d | e ^ ~f << 2 >> 1 <= >= && || * <"
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "a"},
+		{token.PERCENT, "%"},
+		{token.IDENTIFIER, "b"},
+		{token.POWER, "**"},
+		{token.IDENTIFIER, "c"},
+		{token.BIT_AND, "&"},
+		{token.IDENTIFIER, "d"},
+		{token.BIT_OR, "|"},
+		{token.IDENTIFIER, "e"},
+		{token.BIT_XOR, "^"},
+		{token.BIT_NOT, "~"},
+		{token.IDENTIFIER, "f"},
+		{token.SHIFT_LEFT, "<<"},
+		{token.INT, "2"},
+		{token.SHIFT_RIGHT, ">>"},
+		{token.INT, "1"},
+		{token.LT_OR_EQ, "<="},
+		{token.GT_OR_EQ, ">="},
+		{token.AND, "&&"},
+		{token.OR, "||"},
+		{token.ASTERISK, "*"},
+		{token.LT, "<"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+	verifyErrors(t, input)
+}
+
+func TestNextToken_InvalidBytes(t *testing.T) {
+	input := "a\x00b\xff"
+
+	expected := []expectedToken{
+		{token.IDENTIFIER, "a"},
+		{token.ILLEGAL, "\x00"},
+		{token.IDENTIFIER, "b"},
+		{token.ILLEGAL, "\xff"},
+		{token.EOF, ""},
+	}
+
+	verifyTokens(t, input, expected)
+	verifyErrors(t, input,
+		"1:2: unexpected character '\\x00'",
+		"1:4: invalid UTF-8 byte 0xff",
+	)
+}
+
+func TestNextToken_UnterminatedInterpolation(t *testing.T) {
+	verifyTokens(t, `"a ${x`, []expectedToken{
+		{token.STRING_HEAD, "a "},
+		{token.IDENTIFIER, "x"},
+		{token.EOF, ""},
+	})
+	verifyErrors(t, `"a ${x`, "1:1: unterminated string")
+
+	verifyTokens(t, `"a ${x} b`, []expectedToken{
+		{token.STRING_HEAD, "a "},
+		{token.IDENTIFIER, "x"},
+		{token.ILLEGAL, `"a ${x} b`},
+		{token.EOF, ""},
+	})
+	verifyErrors(t, `"a ${x} b`, "1:1: unterminated string")
+}
+
+func TestNextToken_ShortInputs(t *testing.T) {
+	for _, input := range []string{"", " ", "1", "x", "'", "/", "&"} {
+		l := New(input)
+		for i := 0; l.NextToken().Type != token.EOF; i++ {
+			if i > len(input) {
+				t.Fatalf("input %q: lexer does not reach EOF", input)
+			}
+		}
+	}
+}
+
+func FuzzNextToken(f *testing.F) {
+	for _, seed := range []string{
+		"let x = 5;", "'a ${b} c'", "/* a /* b */", "0x1f 1.5e3", "\"\\u{1F600}\"", "a & b",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		l := New(input)
+		// every token consumes at least one byte
+		for i := 0; ; i++ {
+			tok := l.NextToken()
+			if tok.Type == token.EOF {
+				break
+			}
+			if tok.Type == "" {
+				t.Fatalf("token without type at %s", tok.Span.Start)
+			}
+			if i > len(input) {
+				t.Fatalf("lexer does not reach EOF")
+			}
+		}
+		for _, err := range l.Errors() {
+			if !strings.Contains(err.Error(), ": ") {
+				t.Errorf("error without position: %q", err)
+			}
+		}
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 type ArrayObject struct {
 	Items []Object
+	// Frozen arrays cannot be changed, see 'freeze' builtin
+	Frozen bool
 }
 
 func (ao ArrayObject) Inspect() string {
@@ -1,11 +1,22 @@
 package object
 
+import (
+	"fmt"
+
+	"monkey/token"
+)
+
 type ErrorObject struct {
 	Message Object
+	// Pos is start of the innermost node which failed to evaluate
+	Pos token.Position
 }
 
 func (this ErrorObject) Inspect() string {
-	return this.Message.Inspect()
+	if !this.Pos.IsValid() {
+		return this.Message.Inspect()
+	}
+	return fmt.Sprintf("%s: %s", this.Pos, this.Message.Inspect())
 }
 
 func (this ErrorObject) Type() ObjectType {
@@ -0,0 +1,27 @@
+package object
+
+import (
+	"strconv"
+	"strings"
+)
+
+type FloatObject struct {
+	Value float64
+}
+
+// Inspect always keeps float looking like float, so 3.0 is not printed as 3
+func (this FloatObject) Inspect() string {
+	res := strconv.FormatFloat(this.Value, 'g', -1, 64)
+	if !strings.ContainsAny(res, ".eIN") {
+		res += ".0"
+	}
+	return res
+}
+
+func (this FloatObject) Type() ObjectType {
+	return FLOAT
+}
+
+func (this FloatObject) String() string {
+	return this.Inspect()
+}
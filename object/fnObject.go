@@ -2,23 +2,27 @@ package object
 
 import (
 	"fmt"
-	"strings"
 
 	"monkey/ast"
 )
 
 type FnObject struct {
+	// Name is the variable the function was declared with by let or const,
+	// it is empty for anonymous functions
+	Name         string
 	LexicalScope *Scope
 	Arguments    []*ast.Identifier
+	Defaults     []ast.Expression
+	Rest         *ast.Identifier
 	Body         *ast.BlockExpression
 }
 
 func (this FnObject) Inspect() string {
-	args := []string{}
-	for _, arg := range this.Arguments {
-		args = append(args, arg.String())
-	}
-	return fmt.Sprintf("fn (%s) %s", strings.Join(args, ", "), this.Body.String())
+	return fmt.Sprintf(
+		"fn (%s) %s",
+		ast.ParametersString(this.Arguments, this.Defaults, this.Rest),
+		this.Body.String(),
+	)
 }
 
 func (this FnObject) Type() ObjectType {
@@ -1,18 +1,22 @@
 package object
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 )
 
 type HashObject struct {
 	Map map[any]Object
+	// Frozen hashes cannot be changed, see 'freeze' builtin
+	Frozen bool
 }
 
 func (ao HashObject) Inspect() string {
 	args := []string{}
-	for key, val := range ao.Map {
-		args = append(args, fmt.Sprintf("%s:%s", key, val.Inspect()))
+	for _, key := range ao.Keys() {
+		args = append(args, fmt.Sprintf("%v:%s", key, ao.Map[key].Inspect()))
 	}
 	return fmt.Sprintf("#{ %s }", strings.Join(args, ", "))
 }
@@ -20,3 +24,47 @@ func (ao HashObject) Inspect() string {
 func (ao HashObject) Type() ObjectType {
 	return HASH
 }
+
+// Keys returns keys in a stable order: bools (false first), then ints
+// ascending, then strings ascending
+func (ao HashObject) Keys() []any {
+	keys := make([]any, 0, len(ao.Map))
+	for key := range ao.Map {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, compareKeys)
+	return keys
+}
+
+func compareKeys(a, b any) int {
+	if c := cmp.Compare(keyRank(a), keyRank(b)); c != 0 {
+		return c
+	}
+	switch a := a.(type) {
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+		if !a {
+			return -1
+		}
+		return 1
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case string:
+		return cmp.Compare(a, b.(string))
+	default:
+		return 0
+	}
+}
+
+func keyRank(key any) int {
+	switch key.(type) {
+	case bool:
+		return 0
+	case int64:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,24 @@
+package object
+
+// BreakObject and ContinueObject are produced by 'break' and 'continue'
+// and go up through blocks and ifs until the loop, like ReturnObject
+// goes up until the function call
+type BreakObject struct{}
+
+func (this BreakObject) Inspect() string {
+	return "break"
+}
+
+func (this BreakObject) Type() ObjectType {
+	return BREAK
+}
+
+type ContinueObject struct{}
+
+func (this ContinueObject) Inspect() string {
+	return "continue"
+}
+
+func (this ContinueObject) Type() ObjectType {
+	return CONTINUE
+}
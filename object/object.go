@@ -9,21 +9,26 @@ type Object interface {
 
 var (
 	INT        = ObjectType("INT")
+	FLOAT      = ObjectType("FLOAT")
 	BOOL       = ObjectType("BOOL")
 	NULL       = ObjectType("NULL")
 	IF         = ObjectType("IF")
 	RETURN     = ObjectType("RETURN")
+	BREAK      = ObjectType("BREAK")
+	CONTINUE   = ObjectType("CONTINUE")
 	ERROR      = ObjectType("ERROR")
 	STRING     = ObjectType("STRING")
-	IDENT      = ObjectType("IDENT")
 	FN         = ObjectType("FN")
 	BUILTIN_FN = ObjectType("BUILTIN_FN")
 	ARRAY      = ObjectType("ARRAY")
 	HASH       = ObjectType("HASH")
+	RANGE      = ObjectType("RANGE")
 )
 
 var (
-	NULL_OBJECT  = NullObject{}
-	TRUE_OBJECT  = BoolObject{Value: true}
-	FALSE_OBJECT = BoolObject{Value: false}
+	NULL_OBJECT     = NullObject{}
+	BREAK_OBJECT    = BreakObject{}
+	CONTINUE_OBJECT = ContinueObject{}
+	TRUE_OBJECT     = BoolObject{Value: true}
+	FALSE_OBJECT    = BoolObject{Value: false}
 )
@@ -0,0 +1,84 @@
+package object
+
+import (
+	"fmt"
+	"math"
+)
+
+// RangeObject is a lazy sequence of integers from Start going by Step
+// towards Stop, which is included only for inclusive ranges. Elements
+// are computed when needed, range of any length takes the same memory
+type RangeObject struct {
+	Start     int64
+	Stop      int64
+	Step      int64 // never 0
+	Inclusive bool
+}
+
+func (this RangeObject) Inspect() string {
+	operator := ".."
+	if this.Inclusive {
+		operator = "..="
+	}
+	if this.Step == 1 {
+		return fmt.Sprintf("%d%s%d", this.Start, operator, this.Stop)
+	}
+	return fmt.Sprintf("%d%s%d step %d", this.Start, operator, this.Stop, this.Step)
+}
+
+func (this RangeObject) Type() ObjectType {
+	return RANGE
+}
+
+// Len is number of elements, ranges going away from Stop are empty. Range
+// with more than math.MaxInt64 elements says it has math.MaxInt64
+func (this RangeObject) Len() int64 {
+	// distance and step as unsigned, so huge ranges do not overflow
+	var distance, step uint64
+	if this.Step > 0 {
+		if this.Stop < this.Start || (this.Stop == this.Start && !this.Inclusive) {
+			return 0
+		}
+		distance, step = uint64(this.Stop)-uint64(this.Start), uint64(this.Step)
+	} else {
+		if this.Stop > this.Start || (this.Stop == this.Start && !this.Inclusive) {
+			return 0
+		}
+		distance, step = uint64(this.Start)-uint64(this.Stop), -uint64(this.Step)
+	}
+	if !this.Inclusive {
+		// Stop itself is not counted
+		distance--
+	}
+	// count is distance/step + 1, which would not fit for the full int64 span
+	if distance/step >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(distance/step + 1)
+}
+
+// At returns element by index, caller checks that index is within Len
+func (this RangeObject) At(index int64) int64 {
+	return this.Start + index*this.Step
+}
+
+// Contains tells if value is one of the elements
+func (this RangeObject) Contains(value int64) bool {
+	if this.Len() == 0 {
+		return false
+	}
+	// distance from Start and step as unsigned, like in Len
+	var distance, step uint64
+	if this.Step > 0 {
+		if value < this.Start || value > this.Stop || (value == this.Stop && !this.Inclusive) {
+			return false
+		}
+		distance, step = uint64(value)-uint64(this.Start), uint64(this.Step)
+	} else {
+		if value > this.Start || value < this.Stop || (value == this.Stop && !this.Inclusive) {
+			return false
+		}
+		distance, step = uint64(this.Start)-uint64(value), -uint64(this.Step)
+	}
+	return distance%step == 0
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+
+	"monkey/token"
 )
 
 type Scope struct {
 	parent *Scope
 	s      map[string]Object
+	// constants holds declaration positions of names added by AddConst
+	constants map[string]token.Position
 }
 
 func NewGlobalScope() *Scope {
@@ -33,6 +37,34 @@ func (me *Scope) Get(identifier string) (Object, bool) {
 
 func (me *Scope) Add(identifier string, val Object) {
 	me.s[identifier] = val
+	delete(me.constants, identifier)
+}
+
+// AddConst adds a binding which must not be reassigned, pos is where
+// it was declared
+func (me *Scope) AddConst(identifier string, val Object, pos token.Position) {
+	me.s[identifier] = val
+	if me.constants == nil {
+		me.constants = map[string]token.Position{}
+	}
+	me.constants[identifier] = pos
+}
+
+// Constant reports whether the binding identifier refers to is a
+// constant, and where it was declared
+func (me *Scope) Constant(identifier string) (token.Position, bool) {
+	for pointer := me; pointer != nil; pointer = pointer.parent {
+		if _, has := pointer.s[identifier]; has {
+			return pointer.LocalConstant(identifier)
+		}
+	}
+	return token.Position{}, false
+}
+
+// LocalConstant is Constant limited to bindings of this scope
+func (me *Scope) LocalConstant(identifier string) (token.Position, bool) {
+	pos, isConst := me.constants[identifier]
+	return pos, isConst
 }
 
 func (me *Scope) Set(identifier string, val Object) bool {
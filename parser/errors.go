@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"fmt"
+
+	"monkey/lexer"
+	"monkey/token"
+)
+
+// Error is a parse error together with position of the token
+// parser was looking at when it gave up
+type Error struct {
+	Pos token.Position
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func errorPosition(err error) token.Position {
+	switch err := err.(type) {
+	case *Error:
+		return err.Pos
+	case *lexer.Error:
+		return err.Pos
+	default:
+		return token.Position{}
+	}
+}
+
+// addError records err at the current token. Errors on ILLEGAL tokens are
+// dropped, lexer already reported why the token is illegal
+func (p *Parser) addError(err error) {
+	if p.currentToken.Type == token.ILLEGAL {
+		return
+	}
+	p.errors = append(p.errors, &Error{Pos: p.currentToken.Span.Start, Err: err})
+}
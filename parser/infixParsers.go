@@ -13,8 +13,8 @@ func (p *Parser) parseInfixExpression(left ast.Expression) (ast.Expression, erro
 
 	precedence := p.currPrecedence()
 
-	// Assignment is right-associative: x = y = 5 should parse as x = (y = 5)
-	if p.currentToken.Type == token.ASSIGN {
+	// power is right-associative: 2 ** 3 ** 2 is 2 ** (3 ** 2)
+	if p.currentToken.Type == token.POWER {
 		precedence = precedence - 1
 	}
 
@@ -29,6 +29,39 @@ func (p *Parser) parseInfixExpression(left ast.Expression) (ast.Expression, erro
 	return res, nil
 }
 
+// parseAssignExpression parses '=' and compound assignments. Only
+// variables, elements of arrays or hashes and hash fields can be assigned
+// to, anything else on the left like '5 = x' is an error
+func (p *Parser) parseAssignExpression(left ast.Expression) (ast.Expression, error) {
+	defer untrace(trace(fmt.Sprintf("parseAssignExpression, target is %s", left.String())))
+	switch left := left.(type) {
+	case *ast.Identifier:
+	case *ast.IndexExpression:
+		if left.Optional {
+			return nil, fmt.Errorf("cannot assign to optional element %s", left.String())
+		}
+	case *ast.MemberExpression:
+		if left.Optional {
+			return nil, fmt.Errorf("cannot assign to optional field %s", left.String())
+		}
+	default:
+		return nil, fmt.Errorf("cannot assign to %s", left.String())
+	}
+	res := &ast.AssignExpression{Token: p.currentToken, Target: left, Operator: p.currentToken.Literal}
+
+	// go over operator to value
+	p.nextToken()
+
+	// assignment is right-associative: x = y = 5 is x = (y = 5)
+	value, err := p.parseExpression(ASSIGN - 1)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse assigned value: %s", err)
+	}
+	res.Value = value
+
+	return res, nil
+}
+
 func (p *Parser) parseCallExpression(left ast.Expression) (ast.Expression, error) {
 	defer untrace(
 		trace(fmt.Sprintf("parseCallExpression, identifier or literal is %s", left.String())),
@@ -52,29 +85,153 @@ func (p *Parser) parseCallExpression(left ast.Expression) (ast.Expression, error
 		p.nextToken()
 	}
 	res.Arguments = arguments
+	res.Rparen = p.currentToken
+
+	// 'a.method(x)' is a call with receiver
+	if member, isMember := left.(*ast.MemberExpression); isMember {
+		return &ast.MethodCallExpression{
+			Token:     res.Token,
+			Receiver:  member.Object,
+			Method:    member.Property,
+			Arguments: res.Arguments,
+			Rparen:    res.Rparen,
+			Optional:  member.Optional,
+		}, nil
+	}
 
 	return res, nil
 }
 
-// left here is array literal or identifier
+// left here is array literal or identifier, index is in '[...]' or in
+// '?[...]'. When there is ':' inside it is a slice
 func (p *Parser) parseIndexExpression(left ast.Expression) (ast.Expression, error) {
 	defer untrace(
 		trace(fmt.Sprintf("parseIndexExpression, identifier or literal is %s", left.String())),
 	)
-	res := &ast.IndexExpression{Token: p.currentToken, Identifier: left}
+	res := &ast.IndexExpression{
+		Token:      p.currentToken,
+		Identifier: left,
+		Optional:   p.currentToken.Type == token.OPTIONAL_LBRKT,
+	}
 
 	// go from '[' to expression
 	p.nextToken()
+	if token.COLON == p.currentToken.Type {
+		return p.parseSliceExpression(res, nil)
+	}
 	expr, err := p.parseExpression(LOWEST)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse array index expression: %s", err)
 	}
+	if token.COLON == p.peekToken.Type {
+		// go to ':'
+		p.nextToken()
+		return p.parseSliceExpression(res, expr)
+	}
 	res.IndexExpression = expr
 	if token.RBRKT != p.peekToken.Type {
 		return nil, fmt.Errorf("index expression is missing closing ']'")
 	}
 	// go to ']'
 	p.nextToken()
+	res.Rbrkt = p.currentToken
+
+	return res, nil
+}
+
+// parseSliceExpression parses the rest of slice after start, current
+// token is the first ':'
+func (p *Parser) parseSliceExpression(
+	index *ast.IndexExpression,
+	start ast.Expression,
+) (ast.Expression, error) {
+	defer untrace(trace("parseSliceExpression"))
+	res := &ast.SliceExpression{
+		Token:      index.Token,
+		Identifier: index.Identifier,
+		Start:      start,
+		Optional:   index.Optional,
+	}
+
+	var err error
+	if token.COLON != p.peekToken.Type && token.RBRKT != p.peekToken.Type {
+		// go over ':' to stop
+		p.nextToken()
+		res.Stop, err = p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse slice end: %s", err)
+		}
+	}
+	if token.COLON == p.peekToken.Type {
+		// go to second ':'
+		p.nextToken()
+		if token.RBRKT != p.peekToken.Type {
+			// go over ':' to step
+			p.nextToken()
+			res.Step, err = p.parseExpression(LOWEST)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse slice step: %s", err)
+			}
+		}
+	}
+	if token.RBRKT != p.peekToken.Type {
+		return nil, fmt.Errorf("slice expression is missing closing ']'")
+	}
+	// go to ']'
+	p.nextToken()
+	res.Rbrkt = p.currentToken
+
+	return res, nil
+}
+
+// parseMemberExpression parses 'left.name' and 'left?.name'
+func (p *Parser) parseMemberExpression(left ast.Expression) (ast.Expression, error) {
+	defer untrace(trace(fmt.Sprintf("parseMemberExpression, object is %s", left.String())))
+	res := &ast.MemberExpression{
+		Token:    p.currentToken,
+		Object:   left,
+		Optional: p.currentToken.Type == token.OPTIONAL_DOT,
+	}
+
+	// go from '.' to name
+	p.nextToken()
+	if token.IDENTIFIER != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+	}
+	res.Property = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	return res, nil
+}
+
+// parseRangeExpression parses 'start..stop' or 'start..=stop', both
+// can be followed by 'step n'. 'step' is not a keyword, it is recognized
+// only right after the range
+func (p *Parser) parseRangeExpression(left ast.Expression) (ast.Expression, error) {
+	defer untrace(trace(fmt.Sprintf("parseRangeExpression, start is %s", left.String())))
+	res := &ast.RangeExpression{
+		Token:     p.currentToken,
+		Start:     left,
+		Inclusive: p.currentToken.Type == token.DOT_DOT_EQ,
+	}
+
+	// go over '..' to stop
+	p.nextToken()
+	stop, err := p.parseExpression(RANGE)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse range end: %s", err)
+	}
+	res.Stop = stop
+
+	if token.IDENTIFIER == p.peekToken.Type && p.peekToken.Literal == "step" {
+		// go over 'step' to step expression
+		p.nextToken()
+		p.nextToken()
+		step, err := p.parseExpression(RANGE)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse range step: %s", err)
+		}
+		res.Step = step
+	}
 
 	return res, nil
 }
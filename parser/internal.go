@@ -7,31 +7,62 @@ import (
 const (
 	_ int = iota
 	LOWEST
-	ASSIGN      // =
+	ASSIGN      // =, +=, -=, *=, /= or %=
+	NULLISH     // ??
+	OR          // ||
+	AND         // &&
 	EQUALS      // ==
-	LESSGREATER // < or >
+	LESSGREATER // <, >, <=, >= or in
+	PIPE        // |>
+	RANGE       // .. or ..=
+	BIT_OR      // |
+	BIT_XOR     // ^
+	BIT_AND     // &
+	SHIFT       // << or >>
 	SUM         // +
-	PRODUCT     // *
-	BOOL        // && or ||
-	PREFIX      // -X or !X
+	PRODUCT     // *, / or %
+	PREFIX      // -X, !X or ~X
+	POWER       // **, binds tighter than prefix: -2 ** 2 is -(2 ** 2)
 	CALL        // myfunc(X)
-	INDEX       // foo[x]
+	INDEX       // foo[x], foo?[x], foo.x or foo?.x
 )
 
 var precedences = map[token.TokenType]int{
-	token.ASSIGN:   ASSIGN,
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.AND:      BOOL,
-	token.OR:       BOOL,
-	token.LPAREN:   CALL,
-	token.LBRKT:    INDEX,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.PERCENT_ASSIGN:  ASSIGN,
+	token.NULLISH:         NULLISH,
+	token.OR:              OR,
+	token.AND:             AND,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_OR_EQ:        LESSGREATER,
+	token.GT_OR_EQ:        LESSGREATER,
+	token.IN:              LESSGREATER,
+	token.PIPE:            PIPE,
+	token.DOT_DOT:         RANGE,
+	token.DOT_DOT_EQ:      RANGE,
+	token.BIT_OR:          BIT_OR,
+	token.BIT_XOR:         BIT_XOR,
+	token.BIT_AND:         BIT_AND,
+	token.SHIFT_LEFT:      SHIFT,
+	token.SHIFT_RIGHT:     SHIFT,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.PERCENT:         PRODUCT,
+	token.POWER:           POWER,
+	token.LPAREN:          CALL,
+	token.LBRKT:           INDEX,
+	token.OPTIONAL_LBRKT:  INDEX,
+	token.DOT:             INDEX,
+	token.OPTIONAL_DOT:    INDEX,
 }
 
 func (p *Parser) peekPrecedence() int {
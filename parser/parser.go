@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"slices"
 
 	"monkey/ast"
 	"monkey/lexer"
@@ -21,6 +22,10 @@ type (
 		infixParseFns  map[token.TokenType]infixParseFn
 
 		errors []error
+
+		// loopDepth is number of loops around current token inside of
+		// the current function, break and continue are allowed only in loops
+		loopDepth int
 	}
 )
 
@@ -34,9 +39,12 @@ func New(l *lexer.Lexer) *Parser {
 
 	parser.prefixParseFns[token.IDENTIFIER] = parser.parseIdentifierExpression
 	parser.prefixParseFns[token.INT] = parser.parseIntLiteralExpression
+	parser.prefixParseFns[token.FLOAT] = parser.parseFloatLiteralExpression
 	parser.prefixParseFns[token.TRUE] = parser.parseBoolLiteralExpression
 	parser.prefixParseFns[token.FALSE] = parser.parseBoolLiteralExpression
+	parser.prefixParseFns[token.NULL] = parser.parseNullLiteralExpression
 	parser.prefixParseFns[token.STRING] = parser.parseStringLiteralExpression
+	parser.prefixParseFns[token.STRING_HEAD] = parser.parseInterpolatedStringExpression
 	parser.prefixParseFns[token.LPAREN] = parser.parseGroupedExpression
 	parser.prefixParseFns[token.IF] = parser.parseIfExpression
 	parser.prefixParseFns[token.LBRACE] = parser.parseBlockExpression
@@ -47,36 +55,75 @@ func New(l *lexer.Lexer) *Parser {
 	parser.prefixParseFns[token.BANG] = parser.parsePrefixExpression
 	parser.prefixParseFns[token.MINUS] = parser.parsePrefixExpression
 	parser.prefixParseFns[token.PLUS] = parser.parsePrefixExpression
+	parser.prefixParseFns[token.BIT_NOT] = parser.parsePrefixExpression
 
 	parser.infixParseFns[token.PLUS] = parser.parseInfixExpression
 	parser.infixParseFns[token.MINUS] = parser.parseInfixExpression
 	parser.infixParseFns[token.SLASH] = parser.parseInfixExpression
 	parser.infixParseFns[token.ASTERISK] = parser.parseInfixExpression
+	parser.infixParseFns[token.PERCENT] = parser.parseInfixExpression
+	parser.infixParseFns[token.POWER] = parser.parseInfixExpression
 	parser.infixParseFns[token.EQ] = parser.parseInfixExpression
 	parser.infixParseFns[token.NOT_EQ] = parser.parseInfixExpression
 	parser.infixParseFns[token.LT] = parser.parseInfixExpression
 	parser.infixParseFns[token.GT] = parser.parseInfixExpression
+	parser.infixParseFns[token.LT_OR_EQ] = parser.parseInfixExpression
+	parser.infixParseFns[token.GT_OR_EQ] = parser.parseInfixExpression
+	parser.infixParseFns[token.IN] = parser.parseInfixExpression
+	parser.infixParseFns[token.PIPE] = parser.parseInfixExpression
+	parser.infixParseFns[token.DOT_DOT] = parser.parseRangeExpression
+	parser.infixParseFns[token.DOT_DOT_EQ] = parser.parseRangeExpression
+	parser.infixParseFns[token.BIT_AND] = parser.parseInfixExpression
+	parser.infixParseFns[token.BIT_OR] = parser.parseInfixExpression
+	parser.infixParseFns[token.BIT_XOR] = parser.parseInfixExpression
+	parser.infixParseFns[token.SHIFT_LEFT] = parser.parseInfixExpression
+	parser.infixParseFns[token.SHIFT_RIGHT] = parser.parseInfixExpression
 	parser.infixParseFns[token.AND] = parser.parseInfixExpression
 	parser.infixParseFns[token.OR] = parser.parseInfixExpression
-	parser.infixParseFns[token.ASSIGN] = parser.parseInfixExpression
+	parser.infixParseFns[token.NULLISH] = parser.parseInfixExpression
+	parser.infixParseFns[token.ASSIGN] = parser.parseAssignExpression
+	parser.infixParseFns[token.PLUS_ASSIGN] = parser.parseAssignExpression
+	parser.infixParseFns[token.MINUS_ASSIGN] = parser.parseAssignExpression
+	parser.infixParseFns[token.ASTERISK_ASSIGN] = parser.parseAssignExpression
+	parser.infixParseFns[token.SLASH_ASSIGN] = parser.parseAssignExpression
+	parser.infixParseFns[token.PERCENT_ASSIGN] = parser.parseAssignExpression
 
 	parser.infixParseFns[token.LPAREN] = parser.parseCallExpression
 	parser.infixParseFns[token.LBRKT] = parser.parseIndexExpression
+	parser.infixParseFns[token.OPTIONAL_LBRKT] = parser.parseIndexExpression
+	parser.infixParseFns[token.DOT] = parser.parseMemberExpression
+	parser.infixParseFns[token.OPTIONAL_DOT] = parser.parseMemberExpression
 
 	// establish a pointers
-	parser.currentToken = l.NextToken()
-	parser.peekToken = l.NextToken()
+	parser.currentToken = parser.readToken()
+	parser.peekToken = parser.readToken()
 
 	return parser
 }
 
 func (p *Parser) nextToken() {
 	p.currentToken = p.peekToken
-	p.peekToken = p.l.NextToken()
+	p.peekToken = p.readToken()
+}
+
+// readToken returns next meaningful token, comments are skipped
+// even if lexer was asked to keep them
+func (p *Parser) readToken() token.Token {
+	t := p.l.NextToken()
+	for t.Type == token.COMMENT {
+		t = p.l.NextToken()
+	}
+	return t
 }
 
+// Errors returns lexer and parser errors together, ordered by position
 func (p *Parser) Errors() []error {
-	return p.errors
+	errs := append([]error{}, p.l.Errors()...)
+	errs = append(errs, p.errors...)
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return errorPosition(a).Offset - errorPosition(b).Offset
+	})
+	return errs
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
@@ -86,7 +133,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 		trace("parseStatement")
 		statement, err := p.parseStatement()
 		if err != nil {
-			p.errors = append(p.errors, err)
+			p.addError(err)
 			untrace("parseStatement => nil (error)")
 			// Error recovery: synchronize and continue parsing
 			p.skipCurrentStatement()
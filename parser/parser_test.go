@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,14 +36,29 @@ func TestLetStatement(t *testing.T) {
 	assert.Equal(t, int64(5), s.Value.(*ast.IntLiteral).Value)
 }
 
+func TestConstStatement(t *testing.T) {
+	statements, errors := parseStatements("const x = 5; let y = 1;")
+	require.Empty(t, errors)
+
+	require.Len(t, statements, 2)
+	s := statements[0].(*ast.LetStatement)
+	assert.True(t, s.Constant)
+	assert.Equal(t, "x", s.Identifier.Value)
+	assert.Equal(t, "const x = 5;", s.String())
+	assert.False(t, statements[1].(*ast.LetStatement).Constant)
+
+	_, errors = parseStatements("const = 5;")
+	require.Equal(t, []string{"1:7: expected IDENT, got ="}, errors)
+}
+
 func TestLetStatementValidation(t *testing.T) {
 	// missing identifier
 	_, errors := parseStatements("let = 5;")
-	require.Equal(t, []string{"expected IDENT, got ="}, errors)
+	require.Equal(t, []string{"1:5: expected IDENT, got ="}, errors)
 
 	// missing '='
 	_, errors = parseStatements("let nice 5;")
-	require.Equal(t, []string{"expected =, got INT"}, errors)
+	require.Equal(t, []string{"1:10: expected =, got INT"}, errors)
 }
 
 func TestReturnStatement(t *testing.T) {
@@ -55,6 +71,91 @@ func TestReturnStatement(t *testing.T) {
 	assert.Equal(t, "hello", s.Value.(*ast.Identifier).Value)
 }
 
+func TestWhileStatement(t *testing.T) {
+	statements, errors := parseStatements(`while (i < 10) { if (i == 5) { break; } continue; }`)
+	require.Empty(t, errors)
+
+	require.Len(t, statements, 1)
+	require.IsType(t, &ast.WhileStatement{}, statements[0])
+	s := statements[0].(*ast.WhileStatement)
+	assert.Equal(t, "(i < 10)", s.Condition.String())
+	require.Len(t, s.Body.Statements, 2)
+	require.IsType(t, &ast.ContinueStatement{}, s.Body.Statements[1])
+	assert.Equal(t, "while ((i < 10)){if ((i == 5)){break;};continue;}", s.String())
+
+	// statements after loop are parsed as usual
+	statements, errors = parseStatements(`while (x) { x = 0; }; let y = 1;`)
+	require.Empty(t, errors)
+	require.Len(t, statements, 2)
+	require.IsType(t, &ast.LetStatement{}, statements[1])
+}
+
+func TestWhileStatementErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"break;", []string{"1:1: break is not in a loop"}},
+		{"if (x) { continue; }", []string{"1:10: continue is not in a loop"}},
+		{
+			// function body does not belong to the loop around it
+			"while (x) { let f = fn() { break; }; }",
+			[]string{"1:28: break is not in a loop"},
+		},
+		{"while x { }", []string{"1:7: expected (, got IDENT"}},
+		{"while (x) 1;", []string{"1:11: expected {, got INT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			assert.Equal(t, tt.expected, errors)
+		})
+	}
+}
+
+func TestForStatement(t *testing.T) {
+	statements, errors := parseStatements(`for (x in [1, 2]) { continue; }`)
+	require.Empty(t, errors)
+	require.Len(t, statements, 1)
+	require.IsType(t, &ast.ForStatement{}, statements[0])
+	s := statements[0].(*ast.ForStatement)
+	assert.Nil(t, s.Key)
+	assert.Equal(t, "x", s.Value.Value)
+	assert.Equal(t, "[1, 2]", s.Iterable.String())
+	require.Len(t, s.Body.Statements, 1)
+
+	statements, errors = parseStatements(`for (k, v in #{"a": 1}) { break; }; k;`)
+	require.Empty(t, errors)
+	require.Len(t, statements, 2)
+	s = statements[0].(*ast.ForStatement)
+	assert.Equal(t, "k", s.Key.Value)
+	assert.Equal(t, "v", s.Value.Value)
+}
+
+func TestForStatementErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"for x in y {}", []string{"1:5: expected (, got IDENT"}},
+		{"for (1 in y) {}", []string{"1:6: expected IDENT, got INT"}},
+		{"for (x y) {}", []string{"1:8: expected IN, got IDENT"}},
+		{"for (x, 1 in y) {}", []string{"1:9: expected IDENT, got INT"}},
+		{"for (x, x in y) {}", []string{"1:9: loop variable x is declared twice"}},
+		{"for (x in y {}", []string{"1:13: expected ), got {"}},
+		{"for (x in y) x;", []string{"1:14: expected {, got IDENT"}},
+		{"for (x in y) {}; continue;", []string{"1:18: continue is not in a loop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			assert.Equal(t, tt.expected, errors)
+		})
+	}
+}
+
 func TestBlockExpression(t *testing.T) {
 	statements, errors := parseStatements(`{
 		let x = 5;
@@ -471,6 +572,11 @@ func TestIntLiteralExpression(t *testing.T) {
 	}{
 		{"5;", 5},
 		{"10_000;", 10000},
+		{"0xFF;", 255},
+		{"0Xff_ff;", 65535},
+		{"0o755;", 493},
+		{"0b1010_1010;", 170},
+		{"0x7FFF_FFFF_FFFF_FFFF;", 9223372036854775807},
 	}
 
 	for _, tt := range tests {
@@ -488,6 +594,58 @@ func TestIntLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestIntLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		position string
+		message  string
+	}{
+		{"let x = 0x1_0000_0000_0000_0000;", "1:9", "integer literal 0x1_0000_0000_0000_0000 overflows int64"},
+		{"1 +\n99999999999999999999;", "2:1", "integer literal 99999999999999999999 overflows int64"},
+		{"0b102;", "1:1", "invalid digit '2' in binary literal 0b102"},
+		{"0o8;", "1:1", "invalid digit '8' in octal literal 0o8"},
+		{"0xFG;", "1:1", "invalid digit 'G' in hexadecimal literal 0xFG"},
+		{"0x_;", "1:1", "hexadecimal literal 0x_ has no digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			require.Len(t, errors, 1)
+			assert.True(t, strings.HasPrefix(errors[0], tt.position+": "), errors[0])
+			assert.True(t, strings.HasSuffix(errors[0], tt.message), errors[0])
+		})
+	}
+}
+
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e-9;", 1e-9},
+		{"1_000.5;", 1000.5},
+		{"2E3;", 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			statements, errors := parseStatements(tt.input)
+			require.Empty(t, errors)
+
+			require.Len(t, statements, 1)
+			s := statements[0].(*ast.ExpressionStatement)
+			require.IsType(t, &ast.FloatLiteral{}, s.Expression)
+			require.Equal(t, tt.expected, s.Expression.(*ast.FloatLiteral).Value)
+		})
+	}
+
+	_, errors := parseStatements("1e400;")
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0], `could not parse "1e400" as float64`)
+}
+
 func TestStringLiteralExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -512,6 +670,59 @@ func TestStringLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestInterpolatedStringExpression(t *testing.T) {
+	statements, errors := parseStatements(`"count: ${n} of ${total + 1}";`)
+	require.Empty(t, errors)
+
+	require.Len(t, statements, 1)
+	s := statements[0].(*ast.ExpressionStatement)
+	str := s.Expression.(*ast.InterpolatedString)
+	require.IsType(t, &ast.InterpolatedString{}, str)
+
+	require.Len(t, str.Texts, 3)
+	assert.Equal(t, "count: ", str.Texts[0].Value)
+	assert.Equal(t, " of ", str.Texts[1].Value)
+	assert.Equal(t, "", str.Texts[2].Value)
+
+	require.Len(t, str.Expressions, 2)
+	assert.Equal(t, "n", str.Expressions[0].(*ast.Identifier).Value)
+	assert.Equal(t, "(total + 1)", str.Expressions[1].String())
+	assert.Equal(t, `"count: ${n} of ${(total + 1)}"`, str.String())
+}
+
+func TestLexerErrorsAreReported(t *testing.T) {
+	_, errors := parseStatements("let a = \"\\q\";\nlet = 5;\nlet b = '\\x1';")
+	require.Equal(t, []string{
+		`1:10: unknown escape sequence '\q'`,
+		"2:5: expected IDENT, got =",
+		`3:10: invalid escape sequence, '\x' must be followed by two hex digits`,
+	}, errors)
+}
+
+func TestIllegalTokensAreReportedOnce(t *testing.T) {
+	_, errors := parseStatements("let a = 'hello;\n")
+	require.Equal(t, []string{"1:9: unterminated string"}, errors)
+
+	_, errors = parseStatements("let a = b $ c;\nlet d = @;")
+	require.Equal(t, []string{
+		"1:11: unexpected character '$'",
+		"2:9: unexpected character '@'",
+	}, errors)
+
+	_, errors = parseStatements("let a = 1; /* never closed")
+	require.Equal(t, []string{"1:12: unterminated block comment"}, errors)
+}
+
+func TestInterpolatedStringErrors(t *testing.T) {
+	_, errors := parseStatements(`"a ${} b";`)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0], "empty interpolation '${}' in string")
+
+	_, errors = parseStatements(`"a ${x y} b";`)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0], "expected '}' closing string interpolation, got IDENT")
+}
+
 func TestBoolLiteralExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -725,30 +936,104 @@ func TestOperatorPrecedence(t *testing.T) {
 		{"true != false;", "(true != false);"},
 		{"!true == false;", "((!true) == false);"},
 
-		// Logical operators (Note: && and || have higher precedence than arithmetic in this implementation)
+		// Logical operators: below comparisons, && binds tighter than ||
 		{"true && false;", "(true && false);"},
 		{"true || false;", "(true || false);"},
 		{"true && false || true;", "((true && false) || true);"},
-		{"true || false && true;", "((true || false) && true);"},
+		{"true || false && true;", "(true || (false && true));"},
 		{"!true && false;", "((!true) && false);"},
-		// With current precedence: && and || bind tighter than + and *
-		{"a + b && c + d;", "((a + (b && c)) + d);"},
-		{"a * b || c * d;", "((a * (b || c)) * d);"},
+		{"a + b && c + d;", "((a + b) && (c + d));"},
+		{"a * b || c * d;", "((a * b) || (c * d));"},
+		{"x == 1 || y == 2;", "((x == 1) || (y == 2));"},
+		{"a < b && b <= c;", "((a < b) && (b <= c));"},
+		{"a || b || c;", "((a || b) || c);"},
+		{"x = a || b;", "(x = (a || b));"},
 
 		// Unary plus
 		{"+5;", "(+5);"},
 		{"+5 + 3;", "((+5) + 3);"},
 
+		// Comparison with equality
+		{"a <= b == c >= d;", "((a <= b) == (c >= d));"},
+		{"a + 1 <= b * 2;", "((a + 1) <= (b * 2));"},
+
+		// Modulo binds like multiplication
+		{"a + b % c;", "(a + (b % c));"},
+		{"a % b * c;", "((a % b) * c);"},
+
+		// Power is right-associative and binds tighter than prefix operators
+		{"2 ** 3 ** 2;", "(2 ** (3 ** 2));"},
+		{"-2 ** 2;", "(-(2 ** 2));"},
+		{"2 ** -1;", "(2 ** (-1));"},
+		{"a * b ** c;", "(a * (b ** c));"},
+
+		// Bitwise: shifts above arithmetic, then &, ^, | and comparisons
+		{"a | b ^ c & d;", "(a | (b ^ (c & d)));"},
+		{"a & b << 2 + c;", "(a & (b << (2 + c)));"},
+		{"a >> 1 << 2;", "((a >> 1) << 2);"},
+		{"a & 1 == 0;", "((a & 1) == 0);"},
+		{"a | b < c;", "((a | b) < c);"},
+		{"~a & b;", "((~a) & b);"},
+
+		// Ranges bind weaker than arithmetic and stronger than comparisons
+		{"0..n + 1;", "(0..(n + 1));"},
+		{"a * 2..=b;", "((a * 2)..=b);"},
+		{"0..10 step 2;", "(0..10 step 2);"},
+		{"0..10 step n - 1;", "(0..10 step (n - 1));"},
+		{"x in 0..10;", "(x in (0..10));"},
+		{"x in arr && y;", "((x in arr) && y);"},
+		{"x + 1 in arr == true;", "(((x + 1) in arr) == true);"},
+
 		// Assignment operator (lowest precedence, right-associative)
 		{"x = 5;", "(x = 5);"},
 		{"x = 5 + 3;", "(x = (5 + 3));"},
 		{"x = y = 5;", "(x = (y = 5));"},
 		{"x = 5 * 3 + 2;", "(x = ((5 * 3) + 2));"},
+		{"x += 1 + 2;", "(x += (1 + 2));"},
+		{"x -= y *= 2;", "(x -= (y *= 2));"},
+		{"a[0] = 1;", "(a[0] = 1);"},
+		{"h['k'] %= n || 1;", "(h[k] %= (n || 1));"},
+
+		// Pipe binds weaker than arithmetic and ranges, stronger than comparisons
+		{"xs |> map(f) |> filter(g);", "((xs |> map(f)) |> filter(g));"},
+		{"a + b |> f;", "((a + b) |> f);"},
+		{"xs |> len == 3;", "((xs |> len) == 3);"},
+		{"0..10 |> array;", "((0..10) |> array);"},
+		{"x in xs |> f;", "(x in (xs |> f));"},
+		{"a | b |> f;", "((a | b) |> f);"},
+		{"y = x |> f ?? 0;", "(y = ((x |> f) ?? 0));"},
+
+		// Null-safe operators: '??' binds weaker than '||', '?.' and '?[' like indexing
+		{"a ?? b || c;", "(a ?? (b || c));"},
+		{"a ?? b ?? c;", "((a ?? b) ?? c);"},
+		{"x = a == null ?? b;", "(x = ((a == null) ?? b));"},
+		{"cfg?['db']?.host ?? 'localhost';", "(cfg?[db]?.host ?? localhost);"},
+		{"-a?.b;", "(-a?.b);"},
+		{"a?.b[0];", "a?.b[0];"},
+		{"person.address.city;", "person.address.city;"},
+		{"a.b + c.d * 2;", "(a.b + (c.d * 2));"},
+		{"-a.b ** 2;", "(-(a.b ** 2));"},
+		{"f(x).y[0].z;", "f(x).y[0].z;"},
+		{"a.b = c.d = 1;", "(a.b = (c.d = 1));"},
+		{"a.n += 1;", "(a.n += 1);"},
+		{"xs.map(f).filter(g);", "xs.map(f).filter(g);"},
+		{"'abc'.len() + 1;", "(abc.len() + 1);"},
+		{"-a.b.len();", "(-a.b.len());"},
+		{"a?.len() ?? 0;", "(a?.len() ?? 0);"},
+		{"3.float();", "3.float();"},
 
 		// Index expressions (highest precedence)
 		{"a + b[0];", "(a + b[0]);"},
 		{"a * b[0];", "(a * b[0]);"},
 		{"bar()[0];", "bar()[0];"},
+		{"a[1:2];", "a[1:2];"},
+		{"a[:n + 1];", "a[:(n + 1)];"},
+		{"a[1:];", "a[1:];"},
+		{"a[:];", "a[:];"},
+		{"a[::-1];", "a[::(-1)];"},
+		{"a[1:2:3];", "a[1:2:3];"},
+		{"a?[-2:][0];", "a?[(-2):][0];"},
+		{"-a[1:];", "(-a[1:]);"},
 		{"foo[0][1];", "foo[0][1];"},
 	}
 
@@ -763,10 +1048,234 @@ func TestOperatorPrecedence(t *testing.T) {
 	}
 }
 
+func TestAssignExpression(t *testing.T) {
+	statements, errors := parseStatements("a[i] += 2;")
+	require.Empty(t, errors)
+	require.Len(t, statements, 1)
+
+	assign, isAssign := statements[0].(*ast.ExpressionStatement).Expression.(*ast.AssignExpression)
+	require.True(t, isAssign, "expected AssignExpression")
+	assert.Equal(t, "+=", assign.Operator)
+	assert.IsType(t, &ast.IndexExpression{}, assign.Target)
+	assert.Equal(t, "2", assign.Value.String())
+}
+
+func TestNullSafeExpressions(t *testing.T) {
+	statements, errors := parseStatements("null; a?[1]; a?.b;")
+	require.Empty(t, errors)
+	require.Len(t, statements, 3)
+
+	assert.IsType(t, &ast.NullLiteral{}, statements[0].(*ast.ExpressionStatement).Expression)
+
+	index := statements[1].(*ast.ExpressionStatement).Expression.(*ast.IndexExpression)
+	assert.True(t, index.Optional)
+
+	member := statements[2].(*ast.ExpressionStatement).Expression.(*ast.MemberExpression)
+	assert.True(t, member.Optional)
+	assert.Equal(t, "b", member.Property.Value)
+
+	_, errors = parseStatements("a?.1;")
+	assert.Equal(t, []string{"1:4: could not parse expression statement: expected IDENT, got INT"}, errors)
+}
+
+func TestSliceExpression(t *testing.T) {
+	statements, errors := parseStatements("a[1:]; a[::2];")
+	require.Empty(t, errors)
+	require.Len(t, statements, 2)
+
+	slice := statements[0].(*ast.ExpressionStatement).Expression.(*ast.SliceExpression)
+	assert.Equal(t, "1", slice.Start.String())
+	assert.Nil(t, slice.Stop)
+	assert.Nil(t, slice.Step)
+
+	slice = statements[1].(*ast.ExpressionStatement).Expression.(*ast.SliceExpression)
+	assert.Nil(t, slice.Start)
+	assert.Nil(t, slice.Stop)
+	assert.Equal(t, "2", slice.Step.String())
+}
+
+func TestSliceExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a[1:2;", "1:5: could not parse expression statement: slice expression is missing closing ']'"},
+		{"a[1:2:3:4];", "1:7: could not parse expression statement: slice expression is missing closing ']'"},
+		{"a[:)];", "1:4: could not parse expression statement: could not parse slice end: no prefix parse function for ')' found"},
+		{"a[1:] = 2;", "1:7: could not parse expression statement: cannot assign to a[1:]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			require.NotEmpty(t, errors)
+			assert.Equal(t, tt.expected, errors[0])
+		})
+	}
+}
+
+func TestMemberExpression(t *testing.T) {
+	statements, errors := parseStatements("person.address.city = 'Oslo';")
+	require.Empty(t, errors)
+	require.Len(t, statements, 1)
+
+	assign := statements[0].(*ast.ExpressionStatement).Expression.(*ast.AssignExpression)
+	member, isMember := assign.Target.(*ast.MemberExpression)
+	require.True(t, isMember, "expected MemberExpression")
+	assert.False(t, member.Optional)
+	assert.Equal(t, "city", member.Property.Value)
+	assert.Equal(t, "person.address", member.Object.String())
+	assert.Equal(t, "1:16", member.Property.Pos().String())
+
+	_, errors = parseStatements("a.;")
+	assert.Equal(t, []string{"1:3: could not parse expression statement: expected IDENT, got ;"}, errors)
+	_, errors = parseStatements("a.'b';")
+	assert.Equal(t, []string{"1:3: could not parse expression statement: expected IDENT, got STRING"}, errors)
+}
+
+func TestMethodCallExpression(t *testing.T) {
+	statements, errors := parseStatements("person.address.format(1, x);")
+	require.Empty(t, errors)
+	require.Len(t, statements, 1)
+
+	call, isCall := statements[0].(*ast.ExpressionStatement).Expression.(*ast.MethodCallExpression)
+	require.True(t, isCall, "expected MethodCallExpression")
+	assert.Equal(t, "person.address", call.Receiver.String())
+	assert.Equal(t, "format", call.Method.Value)
+	require.Len(t, call.Arguments, 2)
+	assert.False(t, call.Optional)
+
+	_, errors = parseStatements("a.len() = 1;")
+	assert.Equal(t, []string{"1:9: could not parse expression statement: cannot assign to a.len()"}, errors)
+}
+
+func TestFnParameters(t *testing.T) {
+	statements, errors := parseStatements("fn(a, b = 10, ...rest) { a };")
+	require.Empty(t, errors)
+	require.Len(t, statements, 1)
+
+	fn, isFn := statements[0].(*ast.ExpressionStatement).Expression.(*ast.FnExpression)
+	require.True(t, isFn, "expected FnExpression")
+	require.Len(t, fn.Arguments, 2)
+	require.Len(t, fn.Defaults, 2)
+	assert.Nil(t, fn.Defaults[0])
+	assert.Equal(t, "10", fn.Defaults[1].String())
+	require.NotNil(t, fn.Rest)
+	assert.Equal(t, "rest", fn.Rest.Value)
+	assert.Equal(t, "fn(a, b = 10, ...rest){a;}", fn.String())
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(a = 1, b) {};", "1:11: could not parse expression statement: parameter b without default value follows parameter with default value"},
+		{"fn(...rest, a) {};", "1:11: could not parse expression statement: rest parameter ...rest must be the last one"},
+		{"fn(... 1) {};", "1:8: could not parse expression statement: expected IDENT, got INT"},
+		{"fn(a b) {};", "1:4: could not parse expression statement: expected , or ), got IDENT"},
+		{"fn(a", "1:4: could not parse expression statement: fn expression is missing closing ')'"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			require.NotEmpty(t, errors)
+			assert.Equal(t, tt.expected, errors[0])
+		})
+	}
+}
+
+func TestArrowFunction(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x => x * 2;", "fn(x){(x * 2);}"},
+		{"(a, b) => a + b;", "fn(a, b){(a + b);}"},
+		{"() => 1;", "fn(){1;}"},
+		{"(x) => { return x; };", "fn(x){return x;}"},
+		{"a => b => a + b;", "fn(a){fn(b){(a + b);};}"},
+		{"f(x => x, 1);", "f(fn(x){x;}, 1)"},
+		{"x => x |> f;", "fn(x){(x |> f);}"},
+		{"(a);", "a"},
+		{"(a, b = 2) => a + b;", "fn(a, b = 2){(a + b);}"},
+		{"(...xs) => xs;", "fn(...xs){xs;}"},
+		{"(a, ...xs) => xs;", "fn(a, ...xs){xs;}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			statements, errors := parseStatements(tt.input)
+			require.Empty(t, errors)
+			require.Len(t, statements, 1)
+			assert.Equal(t, tt.expected, statements[0].(*ast.ExpressionStatement).Expression.String())
+		})
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"(1, 2) => x;", "1:6: could not parse expression statement: arrow function parameter must be IDENT, got 1"},
+		{"(a, b);", "1:6: could not parse expression statement: expected => after parameters list, got ;"},
+		{"() + 1;", "1:2: could not parse expression statement: expected =>, got +"},
+		{"(a = 1, b) => a;", "1:10: could not parse expression statement: parameter b without default value follows parameter with default value"},
+		{"(...xs, a) => a;", "1:5: could not parse expression statement: rest parameter ...xs must be the last one"},
+		{"(...xs);", "1:7: could not parse expression statement: expected => after parameters list, got ;"},
+		{"(a += 1) => a;", "1:8: could not parse expression statement: arrow function parameter must be IDENT, got (a += 1)"},
+		{"for (i in xs) { x => { break; } }", "1:24: break is not in a loop"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			require.NotEmpty(t, errors)
+			assert.Equal(t, tt.expected, errors[0])
+		})
+	}
+}
+
+func TestAssignExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 = x;", "1:3: could not parse expression statement: cannot assign to 5"},
+		{"f() = 1;", "1:5: could not parse expression statement: cannot assign to f()"},
+		{"a + b = 1;", "1:7: could not parse expression statement: cannot assign to (a + b)"},
+		{"a?[0] = 1;", "1:7: could not parse expression statement: cannot assign to optional element a?[0]"},
+		{"a?.b = 1;", "1:6: could not parse expression statement: cannot assign to optional field a?.b"},
+		{"x = 'a' += 1;", "1:9: could not parse expression statement: could not parse assigned value: cannot assign to a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, errors := parseStatements(tt.input)
+			require.NotEmpty(t, errors)
+			assert.Equal(t, tt.expected, errors[0])
+		})
+	}
+}
+
 // =============================================================================
 // Edge Cases
 // =============================================================================
 
+func TestCommentsAreIgnored(t *testing.T) {
+	input := `// sum of two
+	let x = 1 /* one */ + 2; // two
+	/* block /* nested */ */
+	x;`
+
+	for _, keep := range []bool{false, true} {
+		l := lexer.New(input)
+		l.KeepComments(keep)
+		p := New(l)
+		program := p.ParseProgram()
+		require.Empty(t, p.Errors())
+		require.Equal(t, "let x = (1 + 2);\nx;\n", program.String())
+	}
+}
+
 func TestWhitespaceOnlyInput(t *testing.T) {
 	statements, errors := parseStatements("   \n\t  ")
 	require.Empty(t, errors)
@@ -792,3 +1301,26 @@ func TestIndexExpression_MissingClosingBracketWithTrailing(t *testing.T) {
 	_, errors := parseStatements("foo[1 + 2")
 	assert.NotEmpty(t, errors, "expected parser error for missing closing ']' with infix index")
 }
+
+func TestNodePositions(t *testing.T) {
+	statements, errors := parseStatements("let x = add(1, 2);\nfoo[1] + [3]")
+	require.Empty(t, errors)
+	require.Len(t, statements, 2)
+
+	let := statements[0].(*ast.LetStatement)
+	assert.Equal(t, "1:1", let.Pos().String())
+	assert.Equal(t, "1:18", let.End().String())
+	call := let.Value.(*ast.CallExpression)
+	assert.Equal(t, "1:9", call.Pos().String())
+	assert.Equal(t, "1:18", call.End().String())
+
+	infix := statements[1].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	assert.Equal(t, "2:1", infix.Pos().String())
+	assert.Equal(t, "2:13", infix.End().String())
+	assert.Equal(t, "2:7", infix.Left.End().String())
+}
+
+func TestErrorPositions(t *testing.T) {
+	_, errors := parseStatements("let x = 5;\nlet y = );\nlet z = 1;")
+	require.Equal(t, []string{"2:9: could not parse let statement: no prefix parse function for ')' found"}, errors)
+}
@@ -24,14 +24,56 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 	return res, nil
 }
 
+// parseGroupedExpression parses '(expression)' or parameters of arrow
+// function: '(a, b = 2) => a + b', '(...xs) => xs' and '() => 1'.
+// Parameters are parsed as expressions first, only '=>' after ')' tells
+// them apart, so default value is parsed as assignment
 func (p *Parser) parseGroupedExpression() (ast.Expression, error) {
 	defer untrace(trace("parseGroupedExpression"))
+	start := p.currentToken
+
+	if token.RPAREN == p.peekToken.Type {
+		// go to ')', it must be followed by '=>'
+		p.nextToken()
+		if token.ARROW != p.peekToken.Type {
+			return nil, fmt.Errorf("expected %s, got %s", token.ARROW, p.peekToken.Type)
+		}
+		p.nextToken()
+		return p.parseArrowFunction(start, &ast.FnExpression{
+			Arguments: []*ast.Identifier{},
+			Defaults:  []ast.Expression{},
+		})
+	}
+
 	// go to expression itself
 	p.nextToken()
 
-	expression, err := p.parseExpression(LOWEST)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse grouped expression: %s", err)
+	expressions := []ast.Expression{}
+	var rest *ast.Identifier
+	for {
+		if token.ELLIPSIS == p.currentToken.Type {
+			// rest parameter, it can only be the last one of arrow function
+			p.nextToken()
+			if token.IDENTIFIER != p.currentToken.Type {
+				return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+			}
+			rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+			if token.COMMA == p.peekToken.Type {
+				return nil, fmt.Errorf("rest parameter ...%s must be the last one", rest.Value)
+			}
+			break
+		}
+		expression, err := p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse grouped expression: %s", err)
+		}
+		expressions = append(expressions, expression)
+		if token.COMMA != p.peekToken.Type {
+			break
+		}
+		// go over ',' to the next expression
+		p.nextToken()
+		p.nextToken()
 	}
 	if p.peekToken.Literal != token.RPAREN {
 		return nil, fmt.Errorf("cannot find ')' after parsing '($expression'")
@@ -40,33 +82,193 @@ func (p *Parser) parseGroupedExpression() (ast.Expression, error) {
 	// go over ')'
 	p.nextToken()
 
-	return expression, nil
+	if token.ARROW == p.peekToken.Type {
+		res, err := arrowParameters(expressions)
+		if err != nil {
+			return nil, err
+		}
+		res.Rest = rest
+		// go to '=>'
+		p.nextToken()
+		return p.parseArrowFunction(start, res)
+	}
+	if len(expressions) > 1 || rest != nil {
+		return nil, fmt.Errorf("expected %s after parameters list, got %s", token.ARROW, p.peekToken.Type)
+	}
+
+	return expressions[0], nil
+}
+
+// arrowParameters makes fn expression with parameters from expressions
+// in parentheses before '=>', they are identifiers or assignments of
+// default values to them
+func arrowParameters(expressions []ast.Expression) (*ast.FnExpression, error) {
+	res := &ast.FnExpression{Arguments: []*ast.Identifier{}, Defaults: []ast.Expression{}}
+	for _, expression := range expressions {
+		var value ast.Expression
+		if assign, isAssign := expression.(*ast.AssignExpression); isAssign && assign.Operator == "=" {
+			expression, value = assign.Target, assign.Value
+		}
+		parameter, isIdentifier := expression.(*ast.Identifier)
+		if !isIdentifier {
+			return nil, fmt.Errorf("arrow function parameter must be %s, got %s", token.IDENTIFIER, expression.String())
+		}
+		if err := checkDefault(res, parameter, value); err != nil {
+			return nil, err
+		}
+		res.Arguments = append(res.Arguments, parameter)
+		res.Defaults = append(res.Defaults, value)
+	}
+	return res, nil
+}
+
+// checkDefault checks that parameter without default value does not
+// follow parameters of fn which have it
+func checkDefault(fn *ast.FnExpression, parameter *ast.Identifier, value ast.Expression) error {
+	if value != nil || len(fn.Defaults) == 0 || fn.Defaults[len(fn.Defaults)-1] == nil {
+		return nil
+	}
+	return fmt.Errorf("parameter %s without default value follows parameter with default value", parameter.Value)
 }
 
+// parseIdentifierExpression parses identifier or arrow function with
+// single parameter: 'x => x * 2'
 func (p *Parser) parseIdentifierExpression() (ast.Expression, error) {
 	defer untrace(trace("parseIdentifier"))
-	return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}, nil
+	identifier := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+	if token.ARROW == p.peekToken.Type {
+		// go to '=>'
+		p.nextToken()
+		return p.parseArrowFunction(identifier.Token, &ast.FnExpression{
+			Arguments: []*ast.Identifier{identifier},
+			Defaults:  []ast.Expression{nil},
+		})
+	}
+	return identifier, nil
+}
+
+// parseArrowFunction parses body after '=>' into fn expression with
+// parameters already set. Body is a block, or an expression which becomes
+// the only statement of the block, so it is returned: 'x => x * 2' is
+// 'fn(x) { x * 2 }'
+func (p *Parser) parseArrowFunction(start token.Token, res *ast.FnExpression) (ast.Expression, error) {
+	defer untrace(trace("parseArrowFunction"))
+	// there is no 'fn' keyword, the token covers parameters and '=>'
+	res.Token = token.Token{
+		Type:    token.FUNCTION,
+		Literal: "fn",
+		Span:    token.Span{Start: start.Span.Start, End: p.currentToken.Span.End},
+	}
+
+	// go over '=>' to body
+	p.nextToken()
+
+	// loops around the function do not continue inside of it
+	outerLoopDepth := p.loopDepth
+	p.loopDepth = 0
+	defer func() { p.loopDepth = outerLoopDepth }()
+
+	if token.LBRACE == p.currentToken.Type {
+		body, err := p.parseBlockExpression()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse arrow function body block: %s", err)
+		}
+		res.Body, _ = (body).(*ast.BlockExpression)
+		return res, nil
+	}
+
+	bodyStart := p.currentToken
+	expression, err := p.parseExpression(LOWEST)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse arrow function body: %s", err)
+	}
+	// block has no braces in the source, they are empty at the expression
+	// bounds
+	res.Body = &ast.BlockExpression{
+		Token: token.Token{
+			Type:    token.LBRACE,
+			Literal: "{",
+			Span:    token.Span{Start: expression.Pos(), End: expression.Pos()},
+		},
+		Statements: []ast.Statement{
+			&ast.ExpressionStatement{Token: bodyStart, Expression: expression},
+		},
+		Rbrace: token.Token{
+			Type:    token.RBRACE,
+			Literal: "}",
+			Span:    token.Span{Start: expression.End(), End: expression.End()},
+		},
+	}
+	return res, nil
 }
 
 func (p *Parser) parseIntLiteralExpression() (ast.Expression, error) {
 	defer untrace(trace(fmt.Sprintf("parseIntLiteral = '%s'", p.currentToken.Literal)))
-	// it is number for sure, only possible errors are like 99999999999999999999999999999 or something like that
+	// lexer guarantees it starts as a number, but digits may not fit the base or int64
 	value, err := parseint(p.currentToken.Literal)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse %q as int64: %s", p.currentToken.Literal, err)
+		return nil, err
 	}
 	return &ast.IntLiteral{Token: p.currentToken, Value: value}, nil
 }
 
+func (p *Parser) parseFloatLiteralExpression() (ast.Expression, error) {
+	defer untrace(trace(fmt.Sprintf("parseFloatLiteral = '%s'", p.currentToken.Literal)))
+	// lexer guarantees float syntax, so it can fail only on out of range values like 1e400
+	value, err := parsefloat(p.currentToken.Literal)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %q as float64: %s", p.currentToken.Literal, err)
+	}
+	return &ast.FloatLiteral{Token: p.currentToken, Value: value}, nil
+}
+
 func (p *Parser) parseBoolLiteralExpression() (ast.Expression, error) {
 	defer untrace(trace(fmt.Sprintf("parseBoolLiteral = '%s'", p.currentToken.Literal)))
 	return &ast.BoolLiteral{Token: p.currentToken, Value: parsebool(p.currentToken.Literal)}, nil
 }
 
+func (p *Parser) parseNullLiteralExpression() (ast.Expression, error) {
+	return &ast.NullLiteral{Token: p.currentToken}, nil
+}
+
 func (p *Parser) parseStringLiteralExpression() (ast.Expression, error) {
 	return &ast.StringLiteral{Token: p.currentToken, Value: (p.currentToken.Literal)}, nil
 }
 
+func (p *Parser) parseInterpolatedStringExpression() (ast.Expression, error) {
+	defer untrace(trace("parseInterpolatedString"))
+	res := &ast.InterpolatedString{
+		Token: p.currentToken,
+		Texts: []*ast.StringLiteral{{Token: p.currentToken, Value: p.currentToken.Literal}},
+	}
+
+	for {
+		// go over text part to the expression
+		p.nextToken()
+		if token.STRING_MIDDLE == p.currentToken.Type || token.STRING_TAIL == p.currentToken.Type {
+			return nil, fmt.Errorf("empty interpolation '${}' in string")
+		}
+		expr, err := p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse string interpolation: %s", err)
+		}
+		res.Expressions = append(res.Expressions, expr)
+
+		// go over expression to the text part after '}'
+		p.nextToken()
+		switch p.currentToken.Type {
+		case token.STRING_MIDDLE:
+			res.Texts = append(res.Texts, &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal})
+		case token.STRING_TAIL:
+			res.Texts = append(res.Texts, &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal})
+			res.Tail = p.currentToken
+			return res, nil
+		default:
+			return nil, fmt.Errorf("expected '}' closing string interpolation, got %s", p.currentToken.Type)
+		}
+	}
+}
+
 func (p *Parser) parseIfExpression() (ast.Expression, error) {
 	defer untrace(trace("parseIfExpression"))
 	res := ast.IfExpression{Token: p.currentToken}
@@ -170,7 +372,7 @@ func (p *Parser) parseBlockExpression() (ast.Expression, error) {
 	for token.RBRACE != p.currentToken.Type && token.EOF != p.currentToken.Type {
 		statement, err := p.parseStatement()
 		if err != nil {
-			p.errors = append(p.errors, err)
+			p.addError(err)
 			// Error recovery: synchronize and continue parsing
 			p.skipCurrentStatement()
 			continue
@@ -182,14 +384,18 @@ func (p *Parser) parseBlockExpression() (ast.Expression, error) {
 	if token.RBRACE != p.currentToken.Type {
 		return nil, fmt.Errorf("block expression is missing closing '}'")
 	}
+	res.Rbrace = p.currentToken
 
 	return &res, nil
 }
 
 func (p *Parser) parseFnExpression() (ast.Expression, error) {
 	defer untrace(trace("parseFnExpression"))
-	res := &ast.FnExpression{Token: p.currentToken}
-	arguments := []*ast.Identifier{}
+	res := &ast.FnExpression{
+		Token:     p.currentToken,
+		Arguments: []*ast.Identifier{},
+		Defaults:  []ast.Expression{},
+	}
 
 	// go over 'fn' to '('
 	p.nextToken()
@@ -197,34 +403,65 @@ func (p *Parser) parseFnExpression() (ast.Expression, error) {
 		return nil, fmt.Errorf("expected %s, got %s", token.LPAREN, p.currentToken.Type)
 	}
 
-	for token.RPAREN != p.currentToken.Type && token.EOF != p.currentToken.Type {
-		p.nextToken()
-		if token.RPAREN == p.currentToken.Type {
-			break
+	for token.RPAREN != p.peekToken.Type {
+		if token.EOF == p.peekToken.Type {
+			return nil, fmt.Errorf("fn expression is missing closing ')'")
 		}
-		if token.IDENTIFIER != p.currentToken.Type {
-			return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+		if res.Rest != nil {
+			return nil, fmt.Errorf("rest parameter ...%s must be the last one", res.Rest.Value)
 		}
-		arguments = append(
-			arguments,
-			&ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal},
-		)
-
+		// go to parameter
 		p.nextToken()
-	}
-	res.Arguments = arguments
 
-	// it can be EOF or ')'
-	if token.EOF == p.currentToken.Type {
-		return nil, fmt.Errorf("fn expression is missing closing ')'")
+		switch p.currentToken.Type {
+		case token.ELLIPSIS:
+			// go over '...' to its name
+			p.nextToken()
+			if token.IDENTIFIER != p.currentToken.Type {
+				return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+			}
+			res.Rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+		case token.IDENTIFIER:
+			argument := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+			var value ast.Expression
+			if token.ASSIGN == p.peekToken.Type {
+				// go over '=' to default value
+				p.nextToken()
+				p.nextToken()
+				var err error
+				value, err = p.parseExpression(LOWEST)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse default value of %s: %s", argument.Value, err)
+				}
+			}
+			if err := checkDefault(res, argument, value); err != nil {
+				return nil, err
+			}
+			res.Arguments = append(res.Arguments, argument)
+			res.Defaults = append(res.Defaults, value)
+		default:
+			return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+		}
+
+		if token.COMMA == p.peekToken.Type {
+			p.nextToken()
+		} else if token.RPAREN != p.peekToken.Type && token.EOF != p.peekToken.Type {
+			return nil, fmt.Errorf("expected %s or %s, got %s", token.COMMA, token.RPAREN, p.peekToken.Type)
+		}
 	}
+	// go to ')'
+	p.nextToken()
 
 	// its ')', go over it to '{'
 	p.nextToken()
 	if token.LBRACE != p.currentToken.Type {
 		return nil, fmt.Errorf("expected %s, got %s", token.LBRACE, p.currentToken.Type)
 	}
+	// loops around the function do not continue inside of it
+	outerLoopDepth := p.loopDepth
+	p.loopDepth = 0
 	body, err := p.parseBlockExpression()
+	p.loopDepth = outerLoopDepth
 	if err != nil {
 		return nil, fmt.Errorf("could not parse fn statement body block: %s", err)
 	}
@@ -240,6 +477,7 @@ func (p *Parser) parseArrayExpression() (ast.Expression, error) {
 	// if its '[]' - empty array early return
 	if token.RBRKT == p.peekToken.Type {
 		p.nextToken()
+		res.Rbrkt = p.currentToken
 		return res, nil
 	}
 
@@ -262,6 +500,7 @@ func (p *Parser) parseArrayExpression() (ast.Expression, error) {
 	if token.EOF == p.currentToken.Type {
 		return nil, fmt.Errorf("array expression is missing closing ')'")
 	}
+	res.Rbrkt = p.currentToken
 
 	return res, nil
 }
@@ -316,6 +555,7 @@ func (p *Parser) parseHashExpression() (ast.Expression, error) {
 	if token.EOF == p.currentToken.Type {
 		return nil, fmt.Errorf("hash expression is missing closing '}'")
 	}
+	res.Rbrace = p.currentToken
 
 	return res, nil
 }
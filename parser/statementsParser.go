@@ -9,10 +9,16 @@ import (
 
 func (p *Parser) parseStatement() (ast.Statement, error) {
 	switch p.currentToken.Type {
-	case token.LET:
+	case token.LET, token.CONST:
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -32,13 +38,17 @@ func (p *Parser) parseExpressionStatement() (*ast.ExpressionStatement, error) {
 	return statement, nil
 }
 
+// parseLetStatement parses 'let x = value;' and 'const x = value;'
 func (p *Parser) parseLetStatement() (*ast.LetStatement, error) {
 	defer untrace(trace("parseLetStatement"))
 
-	if token.LET != p.currentToken.Type {
+	if token.LET != p.currentToken.Type && token.CONST != p.currentToken.Type {
 		return nil, fmt.Errorf("expected %s, got %s", token.LET, p.currentToken.Type)
 	}
-	statement := &ast.LetStatement{Token: p.currentToken}
+	statement := &ast.LetStatement{
+		Token:    p.currentToken,
+		Constant: p.currentToken.Type == token.CONST,
+	}
 
 	// move to identifier
 	p.nextToken()
@@ -57,7 +67,7 @@ func (p *Parser) parseLetStatement() (*ast.LetStatement, error) {
 	p.nextToken()
 	expr, err := p.parseExpression(LOWEST)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse let statement: %s", err)
+		return nil, fmt.Errorf("could not parse %s statement: %s", statement.Token.Literal, err)
 	}
 	statement.Value = expr
 
@@ -85,3 +95,122 @@ func (p *Parser) parseReturnStatement() (*ast.ReturnStatement, error) {
 	p.finishStatement()
 	return res, nil
 }
+
+func (p *Parser) parseWhileStatement() (*ast.WhileStatement, error) {
+	defer untrace(trace("parseWhileStatement"))
+	res := &ast.WhileStatement{Token: p.currentToken}
+
+	// proceed to '('
+	p.nextToken()
+	if token.LPAREN != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.LPAREN, p.currentToken.Type)
+	}
+	expr, err := p.parseGroupedExpression()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse while condition: %s", err)
+	}
+	res.Condition = expr
+
+	// proceed to '{'
+	p.nextToken()
+	if token.LBRACE != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.LBRACE, p.currentToken.Type)
+	}
+	p.loopDepth++
+	body, err := p.parseBlockExpression()
+	p.loopDepth--
+	if err != nil {
+		return nil, fmt.Errorf("could not parse while body block: %s", err)
+	}
+	res.Body = body.(*ast.BlockExpression)
+
+	p.finishStatement()
+	return res, nil
+}
+
+func (p *Parser) parseForStatement() (*ast.ForStatement, error) {
+	defer untrace(trace("parseForStatement"))
+	res := &ast.ForStatement{Token: p.currentToken}
+
+	// proceed to '('
+	p.nextToken()
+	if token.LPAREN != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.LPAREN, p.currentToken.Type)
+	}
+
+	// proceed to first variable
+	p.nextToken()
+	if token.IDENTIFIER != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+	}
+	res.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	// with two variables the first one is the key
+	if token.COMMA == p.peekToken.Type {
+		p.nextToken()
+		p.nextToken()
+		if token.IDENTIFIER != p.currentToken.Type {
+			return nil, fmt.Errorf("expected %s, got %s", token.IDENTIFIER, p.currentToken.Type)
+		}
+		res.Key = res.Value
+		res.Value = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+		if res.Key.Value == res.Value.Value {
+			return nil, fmt.Errorf("loop variable %s is declared twice", res.Value.Value)
+		}
+	}
+
+	// proceed to 'in'
+	p.nextToken()
+	if token.IN != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.IN, p.currentToken.Type)
+	}
+
+	// proceed to collection
+	p.nextToken()
+	expr, err := p.parseExpression(LOWEST)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse for loop collection: %s", err)
+	}
+	res.Iterable = expr
+
+	// proceed to ')'
+	p.nextToken()
+	if token.RPAREN != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.RPAREN, p.currentToken.Type)
+	}
+
+	// proceed to '{'
+	p.nextToken()
+	if token.LBRACE != p.currentToken.Type {
+		return nil, fmt.Errorf("expected %s, got %s", token.LBRACE, p.currentToken.Type)
+	}
+	p.loopDepth++
+	body, err := p.parseBlockExpression()
+	p.loopDepth--
+	if err != nil {
+		return nil, fmt.Errorf("could not parse for body block: %s", err)
+	}
+	res.Body = body.(*ast.BlockExpression)
+
+	p.finishStatement()
+	return res, nil
+}
+
+// parseLoopControlStatement parses 'break' or 'continue'
+func (p *Parser) parseLoopControlStatement() (ast.Statement, error) {
+	defer untrace(trace(fmt.Sprintf("parseLoopControlStatement '%s'", p.currentToken.Literal)))
+
+	if p.loopDepth == 0 {
+		return nil, fmt.Errorf("%s is not in a loop", p.currentToken.Literal)
+	}
+
+	var res ast.Statement
+	if token.BREAK == p.currentToken.Type {
+		res = &ast.BreakStatement{Token: p.currentToken}
+	} else {
+		res = &ast.ContinueStatement{Token: p.currentToken}
+	}
+
+	p.finishStatement()
+	return res, nil
+}
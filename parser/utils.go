@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -26,15 +27,64 @@ func untrace(s string) {
 	ident -= 2
 }
 
+// parseint understands decimal, '0x' hexadecimal, '0o' octal and '0b' binary
+// integers with '_' separators. Errors name the literal, so they can be shown as is
 func parseint(intAsString string) (int64, error) {
-	cleanNumber := strings.ReplaceAll(intAsString, "_", "")
-	i, err := strconv.ParseInt(cleanNumber, 10, 64)
+	base, baseName, digits := 10, "decimal", intAsString
+	if len(digits) > 1 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base, baseName, digits = 16, "hexadecimal", digits[2:]
+		case 'o', 'O':
+			base, baseName, digits = 8, "octal", digits[2:]
+		case 'b', 'B':
+			base, baseName, digits = 2, "binary", digits[2:]
+		}
+	}
+
+	cleanNumber := strings.ReplaceAll(digits, "_", "")
+	if cleanNumber == "" {
+		return 0, fmt.Errorf("%s literal %s has no digits", baseName, intAsString)
+	}
+	for _, ch := range cleanNumber {
+		if digitValue(ch) >= base {
+			return 0, fmt.Errorf("invalid digit %q in %s literal %s", ch, baseName, intAsString)
+		}
+	}
+
+	i, err := strconv.ParseInt(cleanNumber, base, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("integer literal %s overflows int64", intAsString)
+	}
 	if err != nil {
 		return 0, err
 	}
 	return i, nil
 }
 
+// digitValue returns value of hexadecimal digit, or 16 for anything else
+func digitValue(ch rune) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch - 'a' + 10)
+	case 'A' <= ch && ch <= 'F':
+		return int(ch - 'A' + 10)
+	default:
+		return 16
+	}
+}
+
+func parsefloat(floatAsString string) (float64, error) {
+	cleanNumber := strings.ReplaceAll(floatAsString, "_", "")
+	f, err := strconv.ParseFloat(cleanNumber, 64)
+	if err != nil {
+		return 0, err
+	}
+	return f, nil
+}
+
 func parsebool(boolAsString string) bool {
 	// This should never fail because lexer guarantees "true" or "false"
 	i, err := strconv.ParseBool(boolAsString)
@@ -1,21 +1,66 @@
 package token
 
+import "fmt"
+
 type TokenType string
 
 type Token struct {
 	Type    TokenType
 	Literal string
+	Span    Span
+}
+
+// Position points at a single character of the source. Offset is in bytes
+// and starts from 0, Line and Column start from 1, Column counts runes.
+// The zero value means "no position".
+type Position struct {
+	Filename string
+	Offset   int
+	Line     int
+	Column   int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String formats position as "file:line:column", or "line:column" when
+// there is no file name, same as go/token does.
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.Filename != "" {
+			return p.Filename
+		}
+		return "-"
+	}
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Span covers token source text, Start is inclusive and End is exclusive.
+type Span struct {
+	Start Position
+	End   Position
 }
 
 func IdentifierToType(word string) TokenType {
 	t, ok := map[string]TokenType{
-		"fn":     FUNCTION,
-		"let":    LET,
-		"if":     IF,
-		"else":   ELSE,
-		"return": RETURN,
-		"true":   TRUE,
-		"false":  FALSE,
+		"fn":       FUNCTION,
+		"let":      LET,
+		"const":    CONST,
+		"if":       IF,
+		"else":     ELSE,
+		"return":   RETURN,
+		"while":    WHILE,
+		"break":    BREAK,
+		"continue": CONTINUE,
+		"for":      FOR,
+		"in":       IN,
+		"true":     TRUE,
+		"false":    FALSE,
+		"null":     NULL,
 	}[word]
 	if ok {
 		return t
@@ -34,11 +79,19 @@ func Empty() Token {
 const (
 	ILLEGAL = "ILLEGAL"
 	EOF     = "EOF"
+	// COMMENT is trivia, lexer emits it only when asked to keep comments
+	COMMENT = "COMMENT"
 
 	// identifiers and literals
 	IDENTIFIER = "IDENT"
 	INT        = "INT"
+	FLOAT      = "FLOAT"
 	STRING     = "STRING"
+	// "head ${a} middle ${b} tail" is lexed as STRING_HEAD, tokens of a,
+	// STRING_MIDDLE, tokens of b, STRING_TAIL, literals hold only the text
+	STRING_HEAD   = "STRING_HEAD"
+	STRING_MIDDLE = "STRING_MIDDLE"
+	STRING_TAIL   = "STRING_TAIL"
 
 	// operators
 	ASSIGN   = "="
@@ -47,11 +100,40 @@ const (
 	BANG     = "!"
 	SLASH    = "/"
 	ASTERISK = "*"
+	PERCENT  = "%"
+	POWER    = "**"
 	EQ       = "=="
 	NOT_EQ   = "!="
 	AND      = "&&"
 	OR       = "||"
 
+	// compound assignment: x += 1 is x = x + 1
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+	PERCENT_ASSIGN  = "%="
+
+	// bitwise operators, integers only
+	BIT_AND     = "&"
+	BIT_OR      = "|"
+	BIT_XOR     = "^"
+	BIT_NOT     = "~"
+	SHIFT_LEFT  = "<<"
+	SHIFT_RIGHT = ">>"
+
+	// arrow function: x => x * 2
+	ARROW = "=>"
+
+	// pipe: x |> f(a) is f(x, a)
+	PIPE = "|>"
+
+	// null-safe operators: a ?? b is b only when a is null, a?.b and a?[i]
+	// are null when a is null
+	NULLISH        = "??"
+	OPTIONAL_DOT   = "?."
+	OPTIONAL_LBRKT = "?["
+
 	// delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
@@ -70,12 +152,29 @@ const (
 	HASH  = "#"
 	COLON = ":"
 
+	// field access: person.name
+	DOT = "."
+
+	// ranges: 0..10 excludes 10, 0..=10 includes it
+	DOT_DOT    = ".."
+	DOT_DOT_EQ = "..="
+
+	// rest parameter: fn(first, ...rest)
+	ELLIPSIS = "..."
+
 	// keywords
 	FUNCTION = "FUNCTION"
 	LET      = "LET"
+	CONST    = "CONST"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	WHILE    = "WHILE"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
+	FOR      = "FOR"
+	IN       = "IN"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
+	NULL     = "NULL"
 )